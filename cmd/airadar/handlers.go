@@ -30,7 +30,7 @@ func loadConfig() (*config.Config, error) {
 	return config.Load(path)
 }
 
-func buildEngine(cfg *config.Config, db store.Store) *trend.Engine {
+func buildEngine(cfg *config.Config, db store.Store, sources []source.Source) *trend.Engine {
 	var llm *trend.LLMEvaluator
 	if cfg.Trend.LLM.Enabled && cfg.Trend.LLM.APIKey != "" {
 		llm = trend.NewLLMEvaluator(
@@ -43,43 +43,33 @@ func buildEngine(cfg *config.Config, db store.Store) *trend.Engine {
 		fmt.Fprintf(os.Stderr, "llm evaluator: %s/%s (min_score: %.0f)\n",
 			cfg.Trend.LLM.Provider, cfg.Trend.LLM.Model, cfg.Trend.LLM.MinScore)
 	}
-	return trend.NewEngine(db, cfg.Trend.VelocityWeight, cfg.Trend.CrossSourceWeight, cfg.Trend.AbsoluteWeight, llm)
-}
-
-func buildSources(cfg *config.Config, filter *source.Filter) []source.Source {
-	var sources []source.Source
 
-	if cfg.Sources.HackerNews.Enabled {
-		sources = append(sources, source.NewHackerNews(cfg.Sources.HackerNews.Limit, filter))
-	}
-	if cfg.Sources.GitHub.Enabled {
-		sources = append(sources, source.NewGitHub(cfg.Sources.GitHub.Token))
-	}
-	if cfg.Sources.Reddit.Enabled {
-		sources = append(sources, source.NewReddit(
-			cfg.Sources.Reddit.ClientID,
-			cfg.Sources.Reddit.ClientSecret,
-			cfg.Sources.Reddit.Subreddits,
-		))
-	}
-	if cfg.Sources.ArXiv.Enabled {
-		sources = append(sources, source.NewArXiv(cfg.Sources.ArXiv.Categories, cfg.Sources.ArXiv.MaxResults))
-	}
-	if cfg.Sources.Twitter.Enabled {
-		sources = append(sources, source.NewTwitter(cfg.Sources.Twitter.NitterURL, cfg.Sources.Twitter.Accounts))
+	weights := make(map[string]float64)
+	for _, src := range sources {
+		inst := source.InstanceOf(src)
+		weights[inst.InstanceName()] = inst.Weight
 	}
-	if cfg.Sources.YouTube.Enabled {
-		sources = append(sources, source.NewYouTube(cfg.Sources.YouTube.APIKey, cfg.Sources.YouTube.Queries, cfg.Sources.YouTube.Channels))
+	return trend.NewEngine(db, cfg.Trend.VelocityWeight, cfg.Trend.CrossSourceWeight, cfg.Trend.AbsoluteWeight, llm, weights)
+}
+
+func buildSources(cfg *config.Config, filter *source.Filter, db store.Store) []source.Source {
+	sources, err := source.Build(cfg.Sources, source.Deps{Filter: filter, Store: db})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
 	}
-	if cfg.Sources.RSS.Enabled {
-		feeds := make([]source.RSSFeed, len(cfg.Sources.RSS.Feeds))
-		for i, f := range cfg.Sources.RSS.Feeds {
-			feeds[i] = source.RSSFeed{Name: f.Name, URL: f.URL}
+	return sources
+}
+
+// hasInstance reports whether the config enables an instance of type t
+// with the given name.
+func hasInstance(cfg *config.Config, db store.Store, t source.SourceType, name string) bool {
+	sources, _ := source.Build(cfg.Sources, source.Deps{Store: db})
+	for _, src := range sources {
+		if inst := source.InstanceOf(src); inst.Type == t && strings.EqualFold(inst.InstanceName(), name) {
+			return true
 		}
-		sources = append(sources, source.NewRSS(feeds, filter))
 	}
-
-	return sources
+	return false
 }
 
 func buildAlertManager(cfg *config.Config) *alert.Manager {
@@ -98,7 +88,16 @@ func buildAlertManager(cfg *config.Config) *alert.Manager {
 	return alert.NewManager(notifiers)
 }
 
-func runCollect(filterSources []string) error {
+func runCollect(filterSources []string, sinceFlag string) error {
+	var since time.Time
+	if sinceFlag != "" {
+		t, err := parseTime("since", sinceFlag, time.Now())
+		if err != nil {
+			return err
+		}
+		since = t
+	}
+
 	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
@@ -111,58 +110,147 @@ func runCollect(filterSources []string) error {
 	defer db.Close()
 
 	filter := source.NewFilter(cfg.Filter.ExtraKeywords, cfg.Filter.ExcludeKeywords)
-	allSources := buildSources(cfg, filter)
+	allSources := buildSources(cfg, filter, db)
 
 	// Filter to requested sources only.
-	var sources []source.Source
+	sources := allSources
 	if len(filterSources) > 0 {
-		wanted := make(map[string]bool)
-		for _, s := range filterSources {
-			wanted[strings.ToLower(strings.TrimSpace(s))] = true
+		sources = source.Select(allSources, filterSources)
+		if len(sources) == 0 {
+			return fmt.Errorf("no matching sources for: %s", strings.Join(filterSources, ", "))
+		}
+	}
+
+	if !since.IsZero() {
+		for _, src := range sources {
+			source.InstanceOf(src).Rewind(since)
 		}
-		for _, s := range allSources {
-			name := string(s.Name())
-			short := shortName(s.Name())
-			if wanted[name] || wanted[short] {
-				sources = append(sources, s)
+	}
+
+	ctx := context.Background()
+	totalItems := 0
+
+	for _, src := range sources {
+		fmt.Fprintf(os.Stderr, "collecting from %s...\n", source.InstanceOf(src).InstanceName())
+		items, err := source.CollectAndStore(ctx, src, db.UpsertItems)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  error: %v\n", err)
+			if items == nil {
+				continue
 			}
 		}
+
+		// Record score snapshots for velocity tracking.
+		for i := range items {
+			_ = db.AddSnapshot(ctx, items[i].ID, items[i].Score, items[i].Comments)
+		}
+
+		fmt.Fprintf(os.Stderr, "  collected %d items\n", len(items))
+		totalItems += len(items)
+	}
+
+	fmt.Fprintf(os.Stderr, "\ntotal: %d items from %d sources\n", totalItems, len(sources))
+	return nil
+}
+
+func runBackfill(filterSources []string, fromFlag, toFlag string) error {
+	now := time.Now()
+	from, err := parseTime("from", fromFlag, now)
+	if err != nil {
+		return err
+	}
+	to := now
+	if toFlag != "" {
+		if to, err = parseTime("to", toFlag, now); err != nil {
+			return err
+		}
+	}
+	if !from.Before(to) {
+		return fmt.Errorf("--from must be before --to")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	db, err := store.New(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("open store: %w", err)
+	}
+	defer db.Close()
+
+	filter := source.NewFilter(cfg.Filter.ExtraKeywords, cfg.Filter.ExcludeKeywords)
+	sources := buildSources(cfg, filter, db)
+	if len(filterSources) > 0 {
+		sources = source.Select(sources, filterSources)
 		if len(sources) == 0 {
 			return fmt.Errorf("no matching sources for: %s", strings.Join(filterSources, ", "))
 		}
-	} else {
-		sources = allSources
 	}
 
 	ctx := context.Background()
-	totalItems := 0
+	totalItems, backfilled := 0, 0
 
 	for _, src := range sources {
-		fmt.Fprintf(os.Stderr, "collecting from %s...\n", src.Name())
-		items, err := src.Collect(ctx)
+		name := source.InstanceOf(src).InstanceName()
+		if !source.CanBackfill(src) {
+			if len(filterSources) > 0 {
+				fmt.Fprintf(os.Stderr, "skipping %s: no historical API\n", name)
+			}
+			continue
+		}
+		backfilled++
+
+		fmt.Fprintf(os.Stderr, "backfilling %s from %s to %s...\n", name,
+			from.Format("2006-01-02 15:04"), to.Format("2006-01-02 15:04"))
+		items, err := source.Backfill(ctx, src, from, to)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "  error: %v\n", err)
 			continue
 		}
 
-		if err := db.UpsertItems(ctx, items); err != nil {
+		if err := db.UpsertBackfillItems(ctx, items); err != nil {
 			fmt.Fprintf(os.Stderr, "  store error: %v\n", err)
 			continue
 		}
 
-		// Record score snapshots for velocity tracking.
+		// Past scores where the upstream keeps them, then today's.
+		snapshots := 0
 		for i := range items {
+			for _, h := range items[i].History {
+				if db.AddSnapshotAt(ctx, items[i].ID, h.Score, h.Comments, h.At) == nil {
+					snapshots++
+				}
+			}
 			_ = db.AddSnapshot(ctx, items[i].ID, items[i].Score, items[i].Comments)
 		}
 
-		fmt.Fprintf(os.Stderr, "  collected %d items\n", len(items))
+		fmt.Fprintf(os.Stderr, "  backfilled %d items, %d past snapshots\n", len(items), snapshots)
 		totalItems += len(items)
 	}
 
-	fmt.Fprintf(os.Stderr, "\ntotal: %d items from %d sources\n", totalItems, len(sources))
+	if backfilled == 0 {
+		return fmt.Errorf("none of the selected sources can backfill")
+	}
+	fmt.Fprintf(os.Stderr, "\ntotal: %d items from %d sources\n", totalItems, backfilled)
 	return nil
 }
 
+// parseTime parses the value of a time flag: a date (2006-01-02), an RFC
+// 3339 time or a duration before now (72h).
+func parseTime(flag, s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid --%s %q: want a date (2006-01-02), RFC 3339 time or duration (72h)", flag, s)
+}
+
 func runTrends(jsonOutput bool, minScore float64, limit int) error {
 	cfg, err := loadConfig()
 	if err != nil {
@@ -176,7 +264,8 @@ func runTrends(jsonOutput bool, minScore float64, limit int) error {
 	defer db.Close()
 
 	// Run trend detection first.
-	engine := buildEngine(cfg, db)
+	filter := source.NewFilter(cfg.Filter.ExtraKeywords, cfg.Filter.ExcludeKeywords)
+	engine := buildEngine(cfg, db, buildSources(cfg, filter, db))
 	if _, err := engine.Detect(context.Background()); err != nil {
 		fmt.Fprintf(os.Stderr, "trend detection error: %v\n", err)
 	}
@@ -230,9 +319,9 @@ func runServe(port int) error {
 	}
 	defer db.Close()
 
-	engine := buildEngine(cfg, db)
 	filter := source.NewFilter(cfg.Filter.ExtraKeywords, cfg.Filter.ExcludeKeywords)
-	sources := buildSources(cfg, filter)
+	sources := buildSources(cfg, filter, db)
+	engine := buildEngine(cfg, db, sources)
 
 	srv := server.New(db, engine, sources, port)
 	return srv.ListenAndServe()
@@ -254,9 +343,9 @@ func runDaemon(port int) error {
 	}
 	defer db.Close()
 
-	engine := buildEngine(cfg, db)
 	filter := source.NewFilter(cfg.Filter.ExtraKeywords, cfg.Filter.ExcludeKeywords)
-	sources := buildSources(cfg, filter)
+	sources := buildSources(cfg, filter, db)
+	engine := buildEngine(cfg, db, sources)
 	alertMgr := buildAlertManager(cfg)
 
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
@@ -285,22 +374,31 @@ func runDaemon(port int) error {
 	return srv.ListenAndServe()
 }
 
-func shortName(st source.SourceType) string {
-	switch st {
-	case source.SourceHackerNews:
-		return "hn"
-	case source.SourceGitHub:
-		return "github"
-	case source.SourceReddit:
-		return "reddit"
-	case source.SourceArXiv:
-		return "arxiv"
-	case source.SourceTwitter:
-		return "twitter"
-	case source.SourceYouTube:
-		return "youtube"
-	case source.SourceRSS:
-		return "rss"
-	}
-	return string(st)
+func runFeedsImport(path, instance string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	feeds, err := source.LoadOPML(path)
+	if err != nil {
+		return err
+	}
+
+	db, err := store.New(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("open store: %w", err)
+	}
+	defer db.Close()
+
+	added, err := db.AddFeeds(context.Background(), instance, feeds)
+	if err != nil {
+		return fmt.Errorf("import feeds: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "imported %d feeds into %s (%d already present)\n", added, instance, len(feeds)-added)
+	if !hasInstance(cfg, db, source.SourceRSS, instance) {
+		fmt.Fprintf(os.Stderr, "note: no enabled rss instance named %q in config\n", instance)
+	}
+	return nil
 }
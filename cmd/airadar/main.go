@@ -3,7 +3,9 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/elonfeng/airadar/pkg/source"
 	"github.com/spf13/cobra"
 )
 
@@ -25,28 +27,72 @@ func rootCmd() *cobra.Command {
 	root.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default: ./config.yaml)")
 
 	root.AddCommand(collectCmd())
+	root.AddCommand(backfillCmd())
 	root.AddCommand(trendsCmd())
 	root.AddCommand(serveCmd())
 	root.AddCommand(runCmd())
+	root.AddCommand(feedsCmd())
 
 	return root
 }
 
 func collectCmd() *cobra.Command {
-	var sources []string
+	var (
+		sources []string
+		since   string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "collect",
 		Short: "Run data collectors",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runCollect(sources)
+			return runCollect(sources, since)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&sources, "source", nil, "sources or instance names to collect: "+sourceNames())
+	cmd.Flags().StringVar(&since, "since", "", "ignore stored cursors and collect from this date or duration ago, e.g. 2026-09-01 or 72h")
+	return cmd
+}
+
+func backfillCmd() *cobra.Command {
+	var (
+		sources  []string
+		from, to string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "backfill",
+		Short: "Collect a past period from historical APIs",
+		Long: `Collect the items published in a past period, e.g. to bootstrap a new
+install or to evaluate trend detection on past events. Supported by
+hackernews, arxiv, reddit and github; other sources are skipped.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBackfill(sources, from, to)
 		},
 	}
 
-	cmd.Flags().StringSliceVar(&sources, "source", nil, "specific sources to collect (e.g., hn,github,rss)")
+	cmd.Flags().StringSliceVar(&sources, "source", nil, "sources or instance names to backfill (default: all that support it)")
+	cmd.Flags().StringVar(&from, "from", "", "start of the period, e.g. 2026-09-01 or 720h")
+	cmd.Flags().StringVar(&to, "to", "", "end of the period, exclusive (default: now)")
+	_ = cmd.MarkFlagRequired("from")
 	return cmd
 }
 
+// sourceNames lists the registered source types for help texts, with their
+// aliases in parentheses.
+func sourceNames() string {
+	var names []string
+	for _, r := range source.Registrations() {
+		name := string(r.Type)
+		if len(r.Aliases) > 0 {
+			name += " (" + strings.Join(r.Aliases, ", ") + ")"
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ", ")
+}
+
 func trendsCmd() *cobra.Command {
 	var (
 		jsonOutput bool
@@ -97,3 +143,24 @@ func runCmd() *cobra.Command {
 	cmd.Flags().IntVar(&port, "port", 8080, "server port")
 	return cmd
 }
+
+func feedsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "feeds",
+		Short: "Manage RSS feeds",
+	}
+
+	var instance string
+	importCmd := &cobra.Command{
+		Use:   "import <file.opml>",
+		Short: "Import RSS feeds from an OPML file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFeedsImport(args[0], instance)
+		},
+	}
+	importCmd.Flags().StringVar(&instance, "instance", string(source.SourceRSS), "RSS instance that collects the feeds")
+	cmd.AddCommand(importCmd)
+
+	return cmd
+}
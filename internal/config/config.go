@@ -5,6 +5,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/elonfeng/airadar/pkg/source"
 	"gopkg.in/yaml.v3"
 )
 
@@ -12,7 +13,7 @@ import (
 type Config struct {
 	Database DatabaseConfig `yaml:"database"`
 	Schedule ScheduleConfig `yaml:"schedule"`
-	Sources  SourcesConfig  `yaml:"sources"`
+	Sources  source.Config  `yaml:"sources"`
 	Trend    TrendConfig    `yaml:"trend"`
 	Alerts   AlertsConfig   `yaml:"alerts"`
 	Server   ServerConfig   `yaml:"server"`
@@ -48,71 +49,6 @@ func (s ScheduleConfig) ParseTrendInterval() time.Duration {
 	return d
 }
 
-// SourcesConfig holds configuration for all data sources.
-type SourcesConfig struct {
-	HackerNews HackerNewsConfig `yaml:"hackernews"`
-	GitHub     GitHubConfig     `yaml:"github"`
-	Reddit     RedditConfig     `yaml:"reddit"`
-	ArXiv      ArXivConfig      `yaml:"arxiv"`
-	Twitter    TwitterConfig    `yaml:"twitter"`
-	YouTube    YouTubeConfig    `yaml:"youtube"`
-	RSS        RSSConfig        `yaml:"rss"`
-}
-
-// HackerNewsConfig for Hacker News collector.
-type HackerNewsConfig struct {
-	Enabled bool `yaml:"enabled"`
-	Limit   int  `yaml:"limit"`
-}
-
-// GitHubConfig for GitHub trending collector.
-type GitHubConfig struct {
-	Enabled bool   `yaml:"enabled"`
-	Token   string `yaml:"token"`
-}
-
-// RedditConfig for Reddit collector.
-type RedditConfig struct {
-	Enabled      bool     `yaml:"enabled"`
-	ClientID     string   `yaml:"client_id"`
-	ClientSecret string   `yaml:"client_secret"`
-	Subreddits   []string `yaml:"subreddits"`
-}
-
-// ArXivConfig for ArXiv collector.
-type ArXivConfig struct {
-	Enabled    bool     `yaml:"enabled"`
-	Categories []string `yaml:"categories"`
-	MaxResults int      `yaml:"max_results"`
-}
-
-// TwitterConfig for Twitter/X collector.
-type TwitterConfig struct {
-	Enabled   bool     `yaml:"enabled"`
-	NitterURL string   `yaml:"nitter_url"`
-	Accounts  []string `yaml:"accounts"`
-}
-
-// YouTubeConfig for YouTube collector.
-type YouTubeConfig struct {
-	Enabled  bool     `yaml:"enabled"`
-	APIKey   string   `yaml:"api_key"`
-	Queries  []string `yaml:"queries"`
-	Channels []string `yaml:"channels"`
-}
-
-// RSSConfig for RSS feed collector.
-type RSSConfig struct {
-	Enabled bool       `yaml:"enabled"`
-	Feeds   []FeedItem `yaml:"feeds"`
-}
-
-// FeedItem is a single RSS feed entry.
-type FeedItem struct {
-	Name string `yaml:"name"`
-	URL  string `yaml:"url"`
-}
-
 // TrendConfig configures trend detection.
 type TrendConfig struct {
 	MinScore          float64   `yaml:"min_score"`
@@ -177,39 +113,7 @@ func Default() *Config {
 			CollectInterval: "15m",
 			TrendInterval:   "30m",
 		},
-		Sources: SourcesConfig{
-			HackerNews: HackerNewsConfig{Enabled: true, Limit: 100},
-			GitHub:     GitHubConfig{Enabled: true},
-			Reddit:     RedditConfig{
-				Enabled: false,
-				Subreddits: []string{
-					"MachineLearning", "artificial", "LocalLLM",
-					"singularity", "ChatGPT", "StableDiffusion",
-				},
-			},
-			ArXiv: ArXivConfig{
-				Enabled:    true,
-				Categories: []string{"cs.AI", "cs.CL", "cs.CV", "cs.LG"},
-				MaxResults: 50,
-			},
-			Twitter: TwitterConfig{
-				Enabled:   false,
-				NitterURL: "https://nitter.net",
-			},
-			YouTube: YouTubeConfig{
-				Enabled: false,
-				Queries: []string{"AI news", "LLM", "artificial intelligence"},
-			},
-			RSS: RSSConfig{
-				Enabled: true,
-				Feeds: []FeedItem{
-					{Name: "TechCrunch AI", URL: "https://techcrunch.com/category/artificial-intelligence/feed/"},
-					{Name: "The Verge AI", URL: "https://www.theverge.com/rss/ai-artificial-intelligence/index.xml"},
-					{Name: "Ars Technica", URL: "https://feeds.arstechnica.com/arstechnica/technology-lab"},
-					{Name: "VentureBeat AI", URL: "https://venturebeat.com/category/ai/feed/"},
-				},
-			},
-		},
+		Sources: source.DefaultConfig(),
 		Trend: TrendConfig{
 			MinScore:          30,
 			VelocityWeight:    0.3,
@@ -249,18 +153,7 @@ func applyEnvOverrides(cfg *Config) {
 	if v := os.Getenv("AIRADAR_DB_PATH"); v != "" {
 		cfg.Database.Path = v
 	}
-	if v := os.Getenv("GITHUB_TOKEN"); v != "" {
-		cfg.Sources.GitHub.Token = v
-	}
-	if v := os.Getenv("REDDIT_CLIENT_ID"); v != "" {
-		cfg.Sources.Reddit.ClientID = v
-	}
-	if v := os.Getenv("REDDIT_CLIENT_SECRET"); v != "" {
-		cfg.Sources.Reddit.ClientSecret = v
-	}
-	if v := os.Getenv("YOUTUBE_API_KEY"); v != "" {
-		cfg.Sources.YouTube.APIKey = v
-	}
+	cfg.Sources.ApplyEnv()
 	if v := os.Getenv("SLACK_WEBHOOK_URL"); v != "" {
 		cfg.Alerts.Slack.WebhookURL = v
 		cfg.Alerts.Slack.Enabled = true
@@ -21,6 +21,8 @@ type Scheduler struct {
 	collectInt time.Duration
 	trendInt   time.Duration
 	minScore   float64
+
+	lastRun map[source.Source]time.Time
 }
 
 // New creates a new scheduler.
@@ -49,12 +51,13 @@ func New(
 		collectInt: collectInt,
 		trendInt:   trendInt,
 		minScore:   minScore,
+		lastRun:    make(map[source.Source]time.Time),
 	}
 }
 
 // Run starts the scheduler loop. Blocks until ctx is cancelled.
 func (s *Scheduler) Run(ctx context.Context) error {
-	collectTicker := time.NewTicker(s.collectInt)
+	collectTicker := time.NewTicker(s.tick())
 	trendTicker := time.NewTicker(s.trendInt)
 	defer collectTicker.Stop()
 	defer trendTicker.Stop()
@@ -74,8 +77,7 @@ func (s *Scheduler) Run(ctx context.Context) error {
 			fmt.Fprintln(os.Stderr, "scheduler: stopped")
 			return ctx.Err()
 		case <-collectTicker.C:
-			fmt.Fprintln(os.Stderr, "scheduler: collecting...")
-			s.collectAll(ctx)
+			s.collectDue(ctx)
 		case <-trendTicker.C:
 			fmt.Fprintln(os.Stderr, "scheduler: detecting trends...")
 			s.detectAndAlert(ctx)
@@ -83,18 +85,62 @@ func (s *Scheduler) Run(ctx context.Context) error {
 	}
 }
 
-func (s *Scheduler) collectAll(ctx context.Context) {
-	totalItems := 0
+// tick is the collect ticker period: the global interval, or the shortest
+// instance interval if one is shorter.
+func (s *Scheduler) tick() time.Duration {
+	tick := s.collectInt
 	for _, src := range s.sources {
-		items, err := src.Collect(ctx)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "  %s error: %v\n", src.Name(), err)
-			continue
+		if d := source.InstanceOf(src).Interval; d > 0 && d < tick {
+			tick = d
 		}
+	}
+	return tick
+}
 
-		if err := s.store.UpsertItems(ctx, items); err != nil {
-			fmt.Fprintf(os.Stderr, "  %s store error: %v\n", src.Name(), err)
-			continue
+// interval returns how often src is collected.
+func (s *Scheduler) interval(src source.Source) time.Duration {
+	if d := source.InstanceOf(src).Interval; d > 0 {
+		return d
+	}
+	return s.collectInt
+}
+
+// collectDue collects the sources whose interval has passed since their
+// last run. Half a tick of slack keeps ticker jitter from skipping a run.
+func (s *Scheduler) collectDue(ctx context.Context) {
+	now := time.Now()
+	slack := s.tick() / 2
+
+	var due []source.Source
+	for _, src := range s.sources {
+		if now.Sub(s.lastRun[src]) >= s.interval(src)-slack {
+			due = append(due, src)
+		}
+	}
+	if len(due) == 0 {
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, "scheduler: collecting...")
+	s.collect(ctx, due)
+}
+
+func (s *Scheduler) collectAll(ctx context.Context) {
+	s.collect(ctx, s.sources)
+}
+
+func (s *Scheduler) collect(ctx context.Context, sources []source.Source) {
+	totalItems := 0
+	for _, src := range sources {
+		s.lastRun[src] = time.Now()
+		name := source.InstanceOf(src).InstanceName()
+
+		items, err := source.CollectAndStore(ctx, src, s.store.UpsertItems)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  %s error: %v\n", name, err)
+			if items == nil {
+				continue
+			}
 		}
 
 		// Record score snapshots.
@@ -102,7 +148,7 @@ func (s *Scheduler) collectAll(ctx context.Context) {
 			_ = s.store.AddSnapshot(ctx, items[i].ID, items[i].Score, items[i].Comments)
 		}
 
-		fmt.Fprintf(os.Stderr, "  %s: %d items\n", src.Name(), len(items))
+		fmt.Fprintf(os.Stderr, "  %s: %d items\n", name, len(items))
 		totalItems += len(items)
 	}
 	fmt.Fprintf(os.Stderr, "  total: %d items\n", totalItems)
@@ -1,9 +1,16 @@
 package store
 
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
 const schema = `
 CREATE TABLE IF NOT EXISTS items (
     id           TEXT PRIMARY KEY,
     source       TEXT NOT NULL,
+    instance     TEXT NOT NULL DEFAULT '',
     external_id  TEXT NOT NULL,
     title        TEXT NOT NULL,
     url          TEXT NOT NULL DEFAULT '',
@@ -47,4 +54,91 @@ CREATE TABLE IF NOT EXISTS trends (
 
 CREATE INDEX IF NOT EXISTS idx_trends_score ON trends(score);
 CREATE INDEX IF NOT EXISTS idx_trends_updated ON trends(last_updated);
+
+CREATE TABLE IF NOT EXISTS feeds (
+    url       TEXT PRIMARY KEY,
+    name      TEXT NOT NULL,
+    instance  TEXT NOT NULL DEFAULT 'rss',
+    added_at  DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS cursors (
+    instance    TEXT NOT NULL,
+    key         TEXT NOT NULL,
+    value       TEXT NOT NULL,
+    updated_at  DATETIME NOT NULL,
+    PRIMARY KEY (instance, key)
+);
+
+CREATE TABLE IF NOT EXISTS page_state (
+    key         TEXT PRIMARY KEY,
+    hash        TEXT NOT NULL,
+    content     TEXT NOT NULL DEFAULT '',
+    checked_at  DATETIME NOT NULL,
+    changed_at  DATETIME NOT NULL
+);
 `
+
+// columnMigrations add columns introduced after a table was first created.
+// CREATE TABLE IF NOT EXISTS leaves existing tables as they are.
+var columnMigrations = []struct {
+	table, column, ddl string
+	backfill           string // run once, right after the column is added
+}{
+	{
+		table:    "items",
+		column:   "instance",
+		ddl:      "ALTER TABLE items ADD COLUMN instance TEXT NOT NULL DEFAULT ''",
+		backfill: "UPDATE items SET instance = source",
+	},
+	{
+		// Feeds imported before instances belong to the default one.
+		table:  "feeds",
+		column: "instance",
+		ddl:    "ALTER TABLE feeds ADD COLUMN instance TEXT NOT NULL DEFAULT 'rss'",
+	},
+}
+
+// Indexes on migrated columns, created once the columns exist, and tables
+// that were replaced (feed_cache: RSS validators, now in cursors).
+const postMigrationSchema = `
+CREATE INDEX IF NOT EXISTS idx_items_instance ON items(instance);
+
+DROP TABLE IF EXISTS feed_cache;
+`
+
+func migrate(db *sqlx.DB) error {
+	if _, err := db.Exec(schema); err != nil {
+		return err
+	}
+
+	for _, m := range columnMigrations {
+		var columns []struct {
+			Name string `db:"name"`
+		}
+		if err := db.Select(&columns, "SELECT name FROM pragma_table_info(?)", m.table); err != nil {
+			return fmt.Errorf("inspect %s: %w", m.table, err)
+		}
+		exists := false
+		for _, c := range columns {
+			if c.Name == m.column {
+				exists = true
+				break
+			}
+		}
+		if exists {
+			continue
+		}
+		if _, err := db.Exec(m.ddl); err != nil {
+			return fmt.Errorf("add %s.%s: %w", m.table, m.column, err)
+		}
+		if m.backfill != "" {
+			if _, err := db.Exec(m.backfill); err != nil {
+				return fmt.Errorf("backfill %s.%s: %w", m.table, m.column, err)
+			}
+		}
+	}
+
+	_, err := db.Exec(postMigrationSchema)
+	return err
+}
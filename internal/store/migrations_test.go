@@ -0,0 +1,95 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/elonfeng/airadar/pkg/source"
+	"github.com/jmoiron/sqlx"
+)
+
+// itemsBeforeInstances is the items table as created before source
+// instances existed.
+const itemsBeforeInstances = `
+CREATE TABLE items (
+    id           TEXT PRIMARY KEY,
+    source       TEXT NOT NULL,
+    external_id  TEXT NOT NULL,
+    title        TEXT NOT NULL,
+    url          TEXT NOT NULL DEFAULT '',
+    description  TEXT NOT NULL DEFAULT '',
+    author       TEXT NOT NULL DEFAULT '',
+    score        INTEGER NOT NULL DEFAULT 0,
+    comments     INTEGER NOT NULL DEFAULT 0,
+    tags         TEXT NOT NULL DEFAULT '[]',
+    published_at DATETIME NOT NULL,
+    collected_at DATETIME NOT NULL,
+    extra        TEXT NOT NULL DEFAULT '{}',
+    UNIQUE(source, external_id)
+);
+CREATE TABLE feed_cache (url TEXT PRIMARY KEY, etag TEXT, last_modified TEXT);
+CREATE TABLE feeds (url TEXT PRIMARY KEY, name TEXT NOT NULL, added_at DATETIME NOT NULL);
+INSERT INTO feeds (url, name, added_at) VALUES ('https://example.com/feed.xml', 'Example', '2025-01-01 00:00:00');
+INSERT INTO items (id, source, external_id, title, published_at, collected_at)
+VALUES ('hackernews:1', 'hackernews', '1', 'Old story', '2025-01-01 00:00:00', '2025-01-01 00:00:00'),
+       ('reddit:2', 'reddit', '2', 'Old post', '2025-01-01 00:00:00', '2025-01-01 00:00:00');
+`
+
+func TestMigrateAddsInstanceColumn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.db")
+	old, err := sqlx.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := old.Exec(itemsBeforeInstances); err != nil {
+		t.Fatalf("create old schema: %v", err)
+	}
+	old.Close()
+
+	s, err := New(path)
+	if err != nil {
+		t.Fatalf("open old database: %v", err)
+	}
+	defer s.Close()
+
+	ctx := context.Background()
+	counts, err := s.CountItemsByInstance(ctx)
+	if err != nil {
+		t.Fatalf("CountItemsByInstance: %v", err)
+	}
+	if counts["hackernews"] != 1 || counts["reddit"] != 1 || len(counts) != 2 {
+		t.Fatalf("instances after migration = %v, want existing rows named after their source", counts)
+	}
+
+	if feeds, err := s.ListFeeds(ctx, "rss"); err != nil || len(feeds) != 1 {
+		t.Fatalf("feeds of the default rss instance = %v, %v, want the imported one", feeds, err)
+	}
+
+	for name, want := range map[string]bool{"cursors": true, "idx_items_instance": true, "feed_cache": false} {
+		var n int
+		if err := s.db.Get(&n, "SELECT count(*) FROM sqlite_master WHERE name = ?", name); err != nil {
+			t.Fatal(err)
+		}
+		if (n == 1) != want {
+			t.Errorf("%s exists = %v, want %v", name, n == 1, want)
+		}
+	}
+
+	// New items record their instance; migrating again changes nothing.
+	item := source.Item{
+		ID: "reddit:3", Source: source.SourceReddit, Instance: "reddit-research", ExternalID: "3",
+		Title: "New post", PublishedAt: time.Now(), CollectedAt: time.Now(),
+	}
+	if err := s.UpsertItems(ctx, []source.Item{item}); err != nil {
+		t.Fatalf("UpsertItems: %v", err)
+	}
+	if err := migrate(s.db); err != nil {
+		t.Fatalf("migrate again: %v", err)
+	}
+	counts, _ = s.CountItemsByInstance(ctx)
+	if counts["reddit-research"] != 1 || counts["reddit"] != 1 {
+		t.Fatalf("instances = %v after a second migration", counts)
+	}
+}
@@ -2,7 +2,9 @@ package store
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -35,9 +37,10 @@ type Trend struct {
 
 // ListOpts controls item listing.
 type ListOpts struct {
-	Source source.SourceType
-	Since  time.Time
-	Limit  int
+	Source   source.SourceType
+	Instance string
+	Since    time.Time
+	Limit    int
 }
 
 // TrendListOpts controls trend listing.
@@ -51,11 +54,16 @@ type TrendListOpts struct {
 type Store interface {
 	UpsertItem(ctx context.Context, item *source.Item) error
 	UpsertItems(ctx context.Context, items []source.Item) error
+	UpsertBackfillItems(ctx context.Context, items []source.Item) error
 	GetItem(ctx context.Context, id string) (*source.Item, error)
 	ListItems(ctx context.Context, opts ListOpts) ([]source.Item, error)
+	ListItemsByInstance(ctx context.Context, instance string, since time.Time, limit int) ([]source.Item, error)
+	LatestPublished(ctx context.Context, instance string) (time.Time, error)
 	CountItemsBySource(ctx context.Context) (map[source.SourceType]int, error)
+	CountItemsByInstance(ctx context.Context) (map[string]int, error)
 
 	AddSnapshot(ctx context.Context, itemID string, score, comments int) error
+	AddSnapshotAt(ctx context.Context, itemID string, score, comments int, at time.Time) error
 	GetSnapshots(ctx context.Context, itemID string, since time.Time) ([]Snapshot, error)
 
 	ClearTrends(ctx context.Context) error
@@ -63,6 +71,15 @@ type Store interface {
 	ListTrends(ctx context.Context, opts TrendListOpts) ([]Trend, error)
 	MarkAlerted(ctx context.Context, trendID int64) error
 
+	AddFeeds(ctx context.Context, instance string, feeds []source.RSSFeed) (int, error)
+	ListFeeds(ctx context.Context, instance string) ([]source.RSSFeed, error)
+
+	GetPageState(ctx context.Context, key string) (hash, content string, err error)
+	SetPageState(ctx context.Context, key, hash, content string) error
+
+	GetCursor(ctx context.Context, instance, key string) (string, error)
+	SetCursor(ctx context.Context, instance, key, value string) error
+
 	Close() error
 }
 
@@ -78,7 +95,7 @@ func New(path string) (*SQLiteStore, error) {
 		return nil, fmt.Errorf("open sqlite %s: %w", path, err)
 	}
 
-	if _, err := db.Exec(schema); err != nil {
+	if err := migrate(db); err != nil {
 		db.Close()
 		return nil, fmt.Errorf("run migrations: %w", err)
 	}
@@ -91,25 +108,13 @@ func (s *SQLiteStore) Close() error {
 }
 
 func (s *SQLiteStore) UpsertItem(ctx context.Context, item *source.Item) error {
-	tagsJSON, _ := json.Marshal(item.Tags)
-	extraJSON, _ := json.Marshal(item.Extra)
-
-	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO items (id, source, external_id, title, url, description, author, score, comments, tags, published_at, collected_at, extra)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-		ON CONFLICT(id) DO UPDATE SET
+	return s.upsertItem(ctx, item, `
+			instance = CASE WHEN items.instance = '' THEN excluded.instance ELSE items.instance END,
 			score = excluded.score,
 			comments = excluded.comments,
 			collected_at = excluded.collected_at,
 			tags = excluded.tags,
-			extra = excluded.extra
-	`, item.ID, item.Source, item.ExternalID, item.Title, item.URL,
-		item.Description, item.Author, item.Score, item.Comments,
-		string(tagsJSON), item.PublishedAt, item.CollectedAt, string(extraJSON))
-	if err != nil {
-		return fmt.Errorf("upsert item %s: %w", item.ID, err)
-	}
-	return nil
+			extra = excluded.extra`)
 }
 
 func (s *SQLiteStore) UpsertItems(ctx context.Context, items []source.Item) error {
@@ -121,6 +126,38 @@ func (s *SQLiteStore) UpsertItems(ctx context.Context, items []source.Item) erro
 	return nil
 }
 
+// UpsertBackfillItems stores historical items. Items already collected only
+// get their current score: their collection time and instance stay, so
+// backfilling doesn't move them back in time or over to another instance.
+func (s *SQLiteStore) UpsertBackfillItems(ctx context.Context, items []source.Item) error {
+	for i := range items {
+		err := s.upsertItem(ctx, &items[i], `
+			score = excluded.score,
+			comments = excluded.comments`)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// upsertItem inserts item, or applies set to the existing row.
+func (s *SQLiteStore) upsertItem(ctx context.Context, item *source.Item, set string) error {
+	tagsJSON, _ := json.Marshal(item.Tags)
+	extraJSON, _ := json.Marshal(item.Extra)
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO items (id, source, instance, external_id, title, url, description, author, score, comments, tags, published_at, collected_at, extra)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET`+set, item.ID, item.Source, item.Instance, item.ExternalID, item.Title, item.URL,
+		item.Description, item.Author, item.Score, item.Comments,
+		string(tagsJSON), item.PublishedAt, item.CollectedAt, string(extraJSON))
+	if err != nil {
+		return fmt.Errorf("upsert item %s: %w", item.ID, err)
+	}
+	return nil
+}
+
 func (s *SQLiteStore) GetItem(ctx context.Context, id string) (*source.Item, error) {
 	var item source.Item
 	err := s.db.GetContext(ctx, &item, "SELECT * FROM items WHERE id = ?", id)
@@ -140,6 +177,10 @@ func (s *SQLiteStore) ListItems(ctx context.Context, opts ListOpts) ([]source.It
 		query += " AND source = ?"
 		args = append(args, opts.Source)
 	}
+	if opts.Instance != "" {
+		query += " AND instance = ?"
+		args = append(args, opts.Instance)
+	}
 	if !opts.Since.IsZero() {
 		query += " AND collected_at >= ?"
 		args = append(args, opts.Since)
@@ -166,6 +207,30 @@ func (s *SQLiteStore) ListItems(ctx context.Context, opts ListOpts) ([]source.It
 	return items, nil
 }
 
+// ListItemsByInstance lets collectors read back what their instance stored
+// on earlier runs.
+func (s *SQLiteStore) ListItemsByInstance(ctx context.Context, instance string, since time.Time, limit int) ([]source.Item, error) {
+	if instance == "" {
+		return nil, fmt.Errorf("list items: instance required")
+	}
+	return s.ListItems(ctx, ListOpts{Instance: instance, Since: since, Limit: limit})
+}
+
+// LatestPublished returns the newest published_at stored by a source
+// instance, or the zero time if there are no items yet.
+func (s *SQLiteStore) LatestPublished(ctx context.Context, instance string) (time.Time, error) {
+	var t time.Time
+	err := s.db.GetContext(ctx, &t,
+		"SELECT published_at FROM items WHERE instance = ? ORDER BY published_at DESC LIMIT 1", instance)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("latest published %s: %w", instance, err)
+	}
+	return t, nil
+}
+
 func (s *SQLiteStore) CountItemsBySource(ctx context.Context) (map[source.SourceType]int, error) {
 	rows, err := s.db.QueryxContext(ctx, "SELECT source, COUNT(*) as cnt FROM items GROUP BY source")
 	if err != nil {
@@ -185,11 +250,38 @@ func (s *SQLiteStore) CountItemsBySource(ctx context.Context) (map[source.Source
 	return counts, nil
 }
 
+// CountItemsByInstance counts items per source instance. An item collected
+// by several instances belongs to the first one.
+func (s *SQLiteStore) CountItemsByInstance(ctx context.Context) (map[string]int, error) {
+	rows, err := s.db.QueryxContext(ctx, "SELECT instance, COUNT(*) as cnt FROM items GROUP BY instance")
+	if err != nil {
+		return nil, fmt.Errorf("count items by instance: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var inst string
+		var cnt int
+		if err := rows.Scan(&inst, &cnt); err != nil {
+			return nil, err
+		}
+		counts[inst] = cnt
+	}
+	return counts, nil
+}
+
 func (s *SQLiteStore) AddSnapshot(ctx context.Context, itemID string, score, comments int) error {
+	return s.AddSnapshotAt(ctx, itemID, score, comments, time.Now())
+}
+
+// AddSnapshotAt records a score observed at a past time, e.g. rebuilt by a
+// backfill.
+func (s *SQLiteStore) AddSnapshotAt(ctx context.Context, itemID string, score, comments int, at time.Time) error {
 	_, err := s.db.ExecContext(ctx, `
 		INSERT INTO score_snapshots (item_id, score, comments, checked_at)
 		VALUES (?, ?, ?, ?)
-	`, itemID, score, comments, time.Now().UTC())
+	`, itemID, score, comments, at.UTC())
 	if err != nil {
 		return fmt.Errorf("add snapshot %s: %w", itemID, err)
 	}
@@ -275,3 +367,95 @@ func (s *SQLiteStore) MarkAlerted(ctx context.Context, trendID int64) error {
 	}
 	return nil
 }
+
+// AddFeeds stores RSS feeds imported into an RSS instance, skipping URLs
+// that already exist (in any instance). Returns the number of newly added
+// feeds.
+func (s *SQLiteStore) AddFeeds(ctx context.Context, instance string, feeds []source.RSSFeed) (int, error) {
+	added := 0
+	now := time.Now().UTC()
+	for _, f := range feeds {
+		res, err := s.db.ExecContext(ctx, `
+			INSERT INTO feeds (url, name, instance, added_at) VALUES (?, ?, ?, ?)
+			ON CONFLICT(url) DO NOTHING
+		`, f.URL, f.Name, instance, now)
+		if err != nil {
+			return added, fmt.Errorf("add feed %s: %w", f.URL, err)
+		}
+		if n, _ := res.RowsAffected(); n > 0 {
+			added++
+		}
+	}
+	return added, nil
+}
+
+// ListFeeds returns the feeds imported into an RSS instance.
+func (s *SQLiteStore) ListFeeds(ctx context.Context, instance string) ([]source.RSSFeed, error) {
+	var feeds []source.RSSFeed
+	if err := s.db.SelectContext(ctx, &feeds, "SELECT name, url FROM feeds WHERE instance = ? ORDER BY added_at, name", instance); err != nil {
+		return nil, fmt.Errorf("list feeds: %w", err)
+	}
+	return feeds, nil
+}
+
+// GetPageState returns the last seen content of a monitored page section.
+// A page that was never checked returns empty strings and no error.
+func (s *SQLiteStore) GetPageState(ctx context.Context, key string) (string, string, error) {
+	var row struct {
+		Hash    string `db:"hash"`
+		Content string `db:"content"`
+	}
+	err := s.db.GetContext(ctx, &row, "SELECT hash, content FROM page_state WHERE key = ?", key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("get page state %s: %w", key, err)
+	}
+	return row.Hash, row.Content, nil
+}
+
+func (s *SQLiteStore) SetPageState(ctx context.Context, key, hash, content string) error {
+	now := time.Now().UTC()
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO page_state (key, hash, content, checked_at, changed_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(key) DO UPDATE SET
+			checked_at = excluded.checked_at,
+			changed_at = CASE WHEN page_state.hash = excluded.hash THEN page_state.changed_at ELSE excluded.changed_at END,
+			hash = excluded.hash,
+			content = excluded.content
+	`, key, hash, content, now, now)
+	if err != nil {
+		return fmt.Errorf("set page state %s: %w", key, err)
+	}
+	return nil
+}
+
+// GetCursor returns a collection cursor of a source instance. A cursor that
+// was never set returns an empty string and no error.
+func (s *SQLiteStore) GetCursor(ctx context.Context, instance, key string) (string, error) {
+	var value string
+	err := s.db.GetContext(ctx, &value, "SELECT value FROM cursors WHERE instance = ? AND key = ?", instance, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get cursor %s/%s: %w", instance, key, err)
+	}
+	return value, nil
+}
+
+func (s *SQLiteStore) SetCursor(ctx context.Context, instance, key, value string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO cursors (instance, key, value, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(instance, key) DO UPDATE SET
+			value = excluded.value,
+			updated_at = excluded.updated_at
+	`, instance, key, value, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("set cursor %s/%s: %w", instance, key, err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/elonfeng/airadar/pkg/source"
+)
+
+func newTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	s, err := New(filepath.Join(t.TempDir(), "airadar.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestUpsertBackfillItems(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	published := time.Date(2026, 2, 1, 8, 0, 0, 0, time.UTC)
+	collected := time.Date(2026, 2, 1, 9, 30, 0, 0, time.UTC)
+	live := source.Item{
+		ID: "hackernews:1", Source: source.SourceHackerNews, Instance: "hn-front", ExternalID: "1",
+		Title: "Story", Score: 40, Comments: 3, Tags: []string{"ai"},
+		PublishedAt: published, CollectedAt: collected,
+	}
+	if err := s.UpsertItems(ctx, []source.Item{live}); err != nil {
+		t.Fatalf("UpsertItems: %v", err)
+	}
+
+	// The backfill finds the same story, and one that was never collected.
+	old := live
+	old.Instance = "hackernews"
+	old.Score, old.Comments = 350, 120
+	old.Tags = nil
+	old.CollectedAt = old.PublishedAt
+	missed := source.Item{
+		ID: "hackernews:2", Source: source.SourceHackerNews, Instance: "hackernews", ExternalID: "2",
+		Title: "Missed story", Score: 90, PublishedAt: published, CollectedAt: published,
+	}
+	if err := s.UpsertBackfillItems(ctx, []source.Item{old, missed}); err != nil {
+		t.Fatalf("UpsertBackfillItems: %v", err)
+	}
+
+	got, err := s.GetItem(ctx, live.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.CollectedAt.Equal(collected) || got.Instance != "hn-front" {
+		t.Errorf("existing item collected at %s by %q, want %s by hn-front", got.CollectedAt, got.Instance, collected)
+	}
+	if got.Score != 350 || got.Comments != 120 {
+		t.Errorf("existing item score %d/%d, want the backfilled 350/120", got.Score, got.Comments)
+	}
+	if len(got.Tags) != 1 {
+		t.Errorf("existing item tags = %v, want them kept", got.Tags)
+	}
+
+	got, err = s.GetItem(ctx, missed.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.CollectedAt.Equal(published) || got.Instance != "hackernews" {
+		t.Errorf("new item collected at %s by %q, want its publish time", got.CollectedAt, got.Instance)
+	}
+}
+
+func TestFeedsPerInstance(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	research := []source.RSSFeed{{Name: "BAIR", URL: "https://bair.berkeley.edu/blog/feed.xml"}, {Name: "Distill", URL: "https://distill.pub/rss.xml"}}
+	press := []source.RSSFeed{{Name: "Verge AI", URL: "https://www.theverge.com/ai/rss"}, research[0]}
+
+	if n, err := s.AddFeeds(ctx, "research", research); err != nil || n != 2 {
+		t.Fatalf("AddFeeds(research) = %d, %v", n, err)
+	}
+	// A feed belongs to the instance that imported it first.
+	if n, err := s.AddFeeds(ctx, "press", press); err != nil || n != 1 {
+		t.Fatalf("AddFeeds(press) = %d, %v, want 1 new", n, err)
+	}
+
+	for instance, want := range map[string]int{"research": 2, "press": 1, "rss": 0} {
+		feeds, err := s.ListFeeds(ctx, instance)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(feeds) != want {
+			t.Errorf("ListFeeds(%s) = %v, want %d feeds", instance, feeds, want)
+		}
+	}
+}
+
+func TestItemsReadBackPerInstance(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	paper := func(id, instance string, published time.Time) source.Item {
+		return source.Item{
+			ID: "arxiv:" + id, Source: source.SourceArXiv, Instance: instance, ExternalID: id,
+			Title: "Paper " + id, PublishedAt: published, CollectedAt: now,
+		}
+	}
+	items := []source.Item{
+		paper("1", "arxiv-nlp", now.Add(-48*time.Hour)),
+		paper("2", "arxiv-vision", now.Add(-time.Hour)),
+		paper("3", "arxiv-nlp", now.Add(-24*time.Hour)),
+	}
+	if err := s.UpsertItems(ctx, items); err != nil {
+		t.Fatalf("UpsertItems: %v", err)
+	}
+
+	latest, err := s.LatestPublished(ctx, "arxiv-nlp")
+	if err != nil || !latest.Equal(now.Add(-24*time.Hour)) {
+		t.Fatalf("LatestPublished(arxiv-nlp) = %v, %v, want its own newest paper", latest, err)
+	}
+	if latest, err := s.LatestPublished(ctx, "arxiv"); err != nil || !latest.IsZero() {
+		t.Fatalf("LatestPublished(arxiv) = %v, %v, want zero", latest, err)
+	}
+
+	stored, err := s.ListItemsByInstance(ctx, "arxiv-vision", now.Add(-time.Hour), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 1 || stored[0].ExternalID != "2" {
+		t.Fatalf("ListItemsByInstance(arxiv-vision) = %v, want paper 2 only", stored)
+	}
+}
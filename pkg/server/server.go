@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/elonfeng/airadar/internal/store"
@@ -82,6 +83,9 @@ func (s *Server) handleItems(w http.ResponseWriter, r *http.Request) {
 	if src := r.URL.Query().Get("source"); src != "" {
 		opts.Source = source.SourceType(src)
 	}
+	if inst := r.URL.Query().Get("instance"); inst != "" {
+		opts.Instance = inst
+	}
 	if since := r.URL.Query().Get("since"); since != "" {
 		if t, err := time.Parse(time.RFC3339, since); err == nil {
 			opts.Since = t
@@ -111,21 +115,63 @@ func (s *Server) handleSources(w http.ResponseWriter, r *http.Request) {
 		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
 		return
 	}
+	instanceCounts, err := s.store.CountItemsByInstance(r.Context())
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
 
+	type instanceInfo struct {
+		Name     string  `json:"name"`
+		Source   string  `json:"source"`
+		Interval string  `json:"interval,omitempty"`
+		Weight   float64 `json:"weight"`
+		Items    int     `json:"items"`
+	}
 	type sourceInfo struct {
-		Name    string `json:"name"`
-		Enabled bool   `json:"enabled"`
-		Items   int    `json:"items"`
+		Name      string         `json:"name"`
+		Aliases   []string       `json:"aliases,omitempty"`
+		Enabled   bool           `json:"enabled"`
+		Items     int            `json:"items"`
+		Instances []instanceInfo `json:"instances,omitempty"`
 	}
 
+	// One entry per registered type, listing the instances it runs. Types
+	// declared in config (json_api, exec) count the items of their instances.
 	var infos []sourceInfo
-	for _, src := range s.sources {
+	index := make(map[source.SourceType]int)
+	for _, reg := range source.Registrations() {
+		index[reg.Type] = len(infos)
 		infos = append(infos, sourceInfo{
-			Name:    string(src.Name()),
-			Enabled: true,
-			Items:   counts[src.Name()],
+			Name:    string(reg.Type),
+			Aliases: reg.Aliases,
+			Items:   counts[reg.Type],
 		})
 	}
+	for _, src := range s.sources {
+		inst := source.InstanceOf(src)
+		i, ok := index[inst.Type]
+		if !ok {
+			index[inst.Type] = len(infos)
+			i = len(infos)
+			infos = append(infos, sourceInfo{Name: string(inst.Type)})
+		}
+
+		info := instanceInfo{
+			Name:   inst.InstanceName(),
+			Source: string(src.Name()),
+			Weight: inst.Weight,
+			Items:  instanceCounts[inst.InstanceName()],
+		}
+		if inst.Interval > 0 {
+			info.Interval = inst.Interval.String()
+		}
+		if src.Name() != inst.Type {
+			infos[i].Items += info.Items
+		}
+		infos[i].Enabled = true
+		infos[i].Instances = append(infos[i].Instances, info)
+	}
 
 	writeJSON(w, http.StatusOK, map[string]any{
 		"data":  infos,
@@ -139,21 +185,29 @@ func (s *Server) handleCollect(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	sources := s.sources
+	if names := r.URL.Query().Get("source"); names != "" {
+		sources = source.Select(s.sources, strings.Split(names, ","))
+		if len(sources) == 0 {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "no matching sources for: " + names})
+			return
+		}
+	}
+
 	ctx := r.Context()
 	results := make(map[string]int)
 	var errs []string
 
-	for _, src := range s.sources {
-		items, err := src.Collect(ctx)
+	for _, src := range sources {
+		name := source.InstanceOf(src).InstanceName()
+		items, err := source.CollectAndStore(ctx, src, s.store.UpsertItems)
 		if err != nil {
-			errs = append(errs, fmt.Sprintf("%s: %v", src.Name(), err))
-			continue
-		}
-		if err := s.store.UpsertItems(ctx, items); err != nil {
-			errs = append(errs, fmt.Sprintf("%s store: %v", src.Name(), err))
-			continue
+			errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+			if items == nil {
+				continue
+			}
 		}
-		results[string(src.Name())] = len(items)
+		results[name] = len(items)
 	}
 
 	resp := map[string]any{"collected": results}
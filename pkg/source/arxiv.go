@@ -5,45 +5,328 @@ import (
 	"encoding/xml"
 	"fmt"
 	"net/http"
+	"os"
+	"regexp"
 	"strings"
 	"time"
 )
 
+// arxivAPIURL is the arXiv search API, replaced in tests.
+var arxivAPIURL = "https://export.arxiv.org/api/query"
+
+// ArXivStore reads back previously collected papers: the newest stored
+// publication date is the harvesting high-water mark, and recent papers are
+// re-enriched as they pick up citations and code.
+type ArXivStore interface {
+	ItemLister
+	LatestPublished(ctx context.Context, instance string) (time.Time, error)
+}
+
+// ArXivEnrichment configures citation and code lookups for papers.
+type ArXivEnrichment struct {
+	Enabled            bool
+	SemanticScholarKey string // optional, raises the rate limit
+	Days               int    // keep re-enriching papers published this recently (default: 7)
+	Limit              int    // max stored papers re-enriched per run (default: 300)
+}
+
 // ArXiv collects recent AI papers from ArXiv.
+//
+// Each run pages back through the newest submissions until it reaches the
+// high-water mark (the newest paper already collected, kept in its cursors),
+// so nothing is missed between runs however busy the categories are. A run
+// that runs out of pages first leaves the mark where it is and records the
+// unread range; later runs page through it by submission date until it is
+// closed.
 type ArXiv struct {
 	client     *http.Client
 	categories []string
-	maxResults int
+	maxResults int // page size
+	maxPages   int
+	store      ArXivStore // optional
+	instance   string     // whose stored papers to read back
+	enrich     ArXivEnrichment
+	cursors    *Cursors
+	pwc        *pwcCache
 }
 
 // NewArXiv creates a new ArXiv collector.
-func NewArXiv(categories []string, maxResults int) *ArXiv {
+func NewArXiv(categories []string, maxResults, maxPages int, store ArXivStore, enrich ArXivEnrichment) *ArXiv {
 	if len(categories) == 0 {
 		categories = []string{"cs.AI", "cs.CL", "cs.CV", "cs.LG"}
 	}
 	if maxResults <= 0 {
 		maxResults = 50
 	}
+	if maxPages <= 0 {
+		maxPages = 5
+	}
+	if enrich.Days <= 0 {
+		enrich.Days = 7
+	}
+	if enrich.Limit <= 0 {
+		enrich.Limit = 300
+	}
 	return &ArXiv{
-		client:     &http.Client{Timeout: 30 * time.Second},
+		client:     newHTTPClient(30 * time.Second),
 		categories: categories,
 		maxResults: maxResults,
+		maxPages:   maxPages,
+		store:      store,
+		enrich:     enrich,
+		cursors:    newCursors(nil, ""),
+		pwc:        newPWCCache(),
+		instance:   string(SourceArXiv),
 	}
 }
 
 func (a *ArXiv) Name() SourceType { return SourceArXiv }
 
+func (a *ArXiv) setInstance(name string) { a.instance = name }
+
+func (a *ArXiv) setCursors(c *Cursors) { a.cursors = c }
+
 func (a *ArXiv) Collect(ctx context.Context) ([]Item, error) {
-	// Build search query: cat:cs.AI OR cat:cs.CL OR ...
+	since, err := a.cursors.Time(ctx, "published")
+	if err != nil {
+		return nil, err
+	}
+	if since.IsZero() && a.store != nil {
+		// Databases from before cursors: resume from the stored papers.
+		since, err = a.store.LatestPublished(ctx, a.instance)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if since.IsZero() {
+		// First run: the last two days cover at least one announcement.
+		since = time.Now().Add(-48 * time.Hour)
+	}
+
+	// A run that hit maxPages before reaching since left the papers
+	// submitted between since and gapBefore unread; the ones up to gapTop
+	// were collected.
+	gapBefore, gapTop := a.gap(ctx)
+	stop := since
+	if !gapBefore.IsZero() {
+		stop = gapTop
+	}
+
+	entries, reached, pages, err := a.harvest(ctx, a.categoryQuery(), stop, a.maxPages)
+	if err != nil {
+		return nil, err
+	}
+	oldest, newest := entrySpan(entries)
+	switch {
+	case reached && gapBefore.IsZero():
+		a.setCursor(ctx, "published", newest)
+	case reached:
+		a.setCursor(ctx, "gap_top", latest(gapTop, newest))
+	case gapBefore.IsZero():
+		// Out of pages: keep the mark, page through the rest next run.
+		a.setCursor(ctx, "published", since) // if it was only a fallback
+		a.setCursor(ctx, "gap_before", oldest)
+		a.setCursor(ctx, "gap_top", newest)
+		// Otherwise the new papers are read again until the run reaches gapTop.
+	}
+
+	if !gapBefore.IsZero() && pages < a.maxPages {
+		query := fmt.Sprintf("(%s)+AND+submittedDate:[%s+TO+%s]", a.categoryQuery(),
+			since.UTC().Format("200601021504"), gapBefore.UTC().Format("200601021504"))
+		older, reached, _, err := a.harvest(ctx, query, since, a.maxPages-pages)
+		if err != nil {
+			fmt.Printf("  arxiv catch-up error: %v\n", err)
+		} else {
+			entries = append(entries, older...)
+			if reached {
+				a.setCursor(ctx, "published", latest(gapTop, newest))
+				a.setCursor(ctx, "gap_before", time.Time{})
+				a.setCursor(ctx, "gap_top", time.Time{})
+			} else if oldest, _ := entrySpan(older); !oldest.IsZero() {
+				a.setCursor(ctx, "gap_before", oldest)
+			}
+		}
+	}
+
+	items := make([]Item, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, entry.toItem())
+	}
+
+	if a.enrich.Enabled {
+		items = append(items, a.recent(ctx, items)...)
+		a.enrichItems(ctx, items)
+	}
+	return items, nil
+}
+
+// harvest pages through the results of query, newest first, until it
+// reaches papers published at or before since, the results end or it has
+// read maxPages. reached reports whether everything after since was read.
+func (a *ArXiv) harvest(ctx context.Context, query string, since time.Time, maxPages int) (entries []arxivEntry, reached bool, pages int, err error) {
+	// Pages are spaced three seconds apart by the arXiv host rate limit.
+	for pages < maxPages {
+		batch, err := a.query(ctx, query, pages*a.maxResults, a.maxResults)
+		if err != nil {
+			if pages == 0 {
+				return nil, false, 0, err
+			}
+			fmt.Printf("  arxiv page %d error: %v\n", pages, err)
+			return entries, false, pages, nil
+		}
+		pages++
+
+		for _, entry := range batch {
+			if !entry.Published.IsZero() && !entry.Published.After(since) {
+				return entries, true, pages, nil
+			}
+			entries = append(entries, entry)
+		}
+		if len(batch) < a.maxResults {
+			return entries, true, pages, nil
+		}
+	}
+	return entries, false, pages, nil
+}
+
+// gap returns the unread range left by an earlier run, if any.
+func (a *ArXiv) gap(ctx context.Context) (before, top time.Time) {
+	b, err := a.cursors.Get(ctx, "gap_before")
+	if err != nil || b == "" {
+		return time.Time{}, time.Time{}
+	}
+	t, _ := a.cursors.Get(ctx, "gap_top")
+	return parseCursorTime(b), parseCursorTime(t)
+}
+
+// setCursor stores a time cursor; the zero time clears it.
+func (a *ArXiv) setCursor(ctx context.Context, key string, t time.Time) {
+	var err error
+	switch {
+	case key == "published":
+		err = a.cursors.Advance(ctx, key, t)
+	case t.IsZero():
+		err = a.cursors.Set(ctx, key, "")
+	default:
+		err = a.cursors.Set(ctx, key, t.UTC().Format(time.RFC3339Nano))
+	}
+	if err != nil {
+		fmt.Printf("  arxiv cursor error: %v\n", err)
+	}
+}
+
+// entrySpan returns the oldest and newest publication dates of entries.
+func entrySpan(entries []arxivEntry) (oldest, newest time.Time) {
+	for _, e := range entries {
+		if e.Published.IsZero() {
+			continue
+		}
+		if oldest.IsZero() || e.Published.Before(oldest) {
+			oldest = e.Published
+		}
+		if e.Published.After(newest) {
+			newest = e.Published
+		}
+	}
+	return oldest, newest
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// recent returns stored papers still inside the enrichment window that are
+// not part of this run, so their scores keep updating.
+func (a *ArXiv) recent(ctx context.Context, fresh []Item) []Item {
+	if a.store == nil {
+		return nil
+	}
+
+	window := time.Now().AddDate(0, 0, -a.enrich.Days)
+	stored, err := a.store.ListItemsByInstance(ctx, a.instance, window, a.enrich.Limit)
+	if err != nil {
+		fmt.Printf("  arxiv re-enrich error: %v\n", err)
+		return nil
+	}
+
+	skip := make(map[string]bool, len(fresh))
+	for _, item := range fresh {
+		skip[item.ID] = true
+	}
+
+	var items []Item
+	for _, item := range stored {
+		if skip[item.ID] || item.PublishedAt.Before(window) {
+			continue
+		}
+		item.CollectedAt = time.Now().UTC()
+		items = append(items, item)
+	}
+	return items
+}
+
+// arxivBackfillPage is the page size of backfill queries; a month of the
+// default categories is over ten thousand papers.
+const arxivBackfillPage = 500
+
+// Backfill returns the papers submitted between from and to. Citation counts
+// are looked up when enrichment is on; Papers with Code is skipped, one
+// request per paper is too slow for a backfill.
+func (a *ArXiv) Backfill(ctx context.Context, from, to time.Time) ([]Item, error) {
+	// ArXiv API expects unencoded +AND+ and brackets, like the +OR+ below.
+	query := fmt.Sprintf("(%s)+AND+submittedDate:[%s+TO+%s]", a.categoryQuery(),
+		from.UTC().Format("200601021504"), to.UTC().Format("200601021504"))
+
+	var items []Item
+	for start := 0; ; start += arxivBackfillPage {
+		batch, err := a.query(ctx, query, start, arxivBackfillPage)
+		if err != nil {
+			if start == 0 {
+				return nil, err
+			}
+			fmt.Printf("  arxiv backfill page %d error: %v\n", start/arxivBackfillPage, err)
+			break
+		}
+		for _, entry := range batch {
+			items = append(items, entry.toItem())
+		}
+		if len(batch) < arxivBackfillPage {
+			break
+		}
+	}
+
+	if a.enrich.Enabled {
+		for start := 0; start < len(items); start += s2BatchMax {
+			end := min(start+s2BatchMax, len(items))
+			if err := a.semanticScholar(ctx, items[start:end]); err != nil {
+				fmt.Printf("  arxiv semantic scholar error: %v\n", err)
+			}
+		}
+		for i := range items {
+			items[i].Score = extraInt(items[i].Extra, "citations")
+		}
+	}
+	return items, nil
+}
+
+// categoryQuery is the search query for the configured categories:
+// cat:cs.AI+OR+cat:cs.CL+OR+...
+func (a *ArXiv) categoryQuery() string {
 	var parts []string
 	for _, cat := range a.categories {
 		parts = append(parts, "cat:"+cat)
 	}
-	query := strings.Join(parts, "+OR+")
+	return strings.Join(parts, "+OR+")
+}
 
+// query fetches one page of search results, newest submissions first.
+func (a *ArXiv) query(ctx context.Context, query string, start, maxResults int) ([]arxivEntry, error) {
 	// ArXiv API expects unencoded +OR+ in the search query, so build URL manually.
-	reqURL := fmt.Sprintf("https://export.arxiv.org/api/query?search_query=%s&sortBy=submittedDate&sortOrder=descending&max_results=%d",
-		query, a.maxResults)
+	reqURL := fmt.Sprintf("%s?search_query=%s&sortBy=submittedDate&sortOrder=descending&start=%d&max_results=%d",
+		arxivAPIURL, query, start, maxResults)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("create arxiv request: %w", err)
@@ -55,55 +338,65 @@ func (a *ArXiv) Collect(ctx context.Context) ([]Item, error) {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("arxiv status %d", resp.StatusCode)
+	if err := checkStatus(resp); err != nil {
+		return nil, fmt.Errorf("arxiv: %w", err)
 	}
 
 	var feed arxivFeed
 	if err := xml.NewDecoder(resp.Body).Decode(&feed); err != nil {
 		return nil, fmt.Errorf("decode arxiv: %w", err)
 	}
+	return feed.Entries, nil
+}
 
-	var items []Item
-	for _, entry := range feed.Entries {
-		// Extract paper ID from URL (e.g., "http://arxiv.org/abs/2402.12345v1" -> "2402.12345")
-		paperID := extractArXivID(entry.ID)
+func (entry arxivEntry) toItem() Item {
+	// Extract paper ID from URL (e.g., "http://arxiv.org/abs/2402.12345v1" -> "2402.12345")
+	paperID := extractArXivID(entry.ID)
 
-		var tags []string
-		for _, cat := range entry.Categories {
-			tags = append(tags, cat.Term)
-		}
+	var tags []string
+	for _, cat := range entry.Categories {
+		tags = append(tags, cat.Term)
+	}
 
-		var authors []string
-		for _, a := range entry.Authors {
-			authors = append(authors, a.Name)
-		}
-		author := strings.Join(authors, ", ")
+	var authors []string
+	for _, a := range entry.Authors {
+		authors = append(authors, a.Name)
+	}
+	author := strings.Join(authors, ", ")
 
-		published := entry.Published
-		if published.IsZero() {
-			published = time.Now().UTC()
-		}
+	published := entry.Published
+	if published.IsZero() {
+		published = time.Now().UTC()
+	}
 
-		items = append(items, Item{
-			ID:          fmt.Sprintf("arxiv:%s", paperID),
-			Source:      SourceArXiv,
-			ExternalID:  paperID,
-			Title:       strings.TrimSpace(entry.Title),
-			URL:         entry.ID,
-			Description: truncate(strings.TrimSpace(entry.Summary), 500),
-			Author:      author,
-			Score:       0, // ArXiv has no upvote system
-			Tags:        tags,
-			PublishedAt: published,
-			CollectedAt: time.Now().UTC(),
-			Extra: map[string]any{
-				"categories": tags,
-			},
-		})
+	return Item{
+		ID:          fmt.Sprintf("arxiv:%s", paperID),
+		Source:      SourceArXiv,
+		ExternalID:  paperID,
+		Title:       strings.TrimSpace(entry.Title),
+		URL:         entry.ID,
+		Description: truncate(strings.TrimSpace(entry.Summary), 500),
+		Author:      author,
+		Score:       0, // filled in by enrichment: citations + code stars
+		Tags:        tags,
+		PublishedAt: published.UTC(),
+		CollectedAt: time.Now().UTC(),
+		Extra: map[string]any{
+			"arxiv_id":   paperID,
+			"categories": tags,
+		},
 	}
+}
 
-	return items, nil
+var arxivURLRe = regexp.MustCompile(`^https?://(?:www\.|export\.)?arxiv\.org/(?:abs|pdf|html)/([a-z-]+/\d{7}|\d{4}\.\d{4,5})`)
+
+// ArXivIDFromURL returns the version-less arXiv ID of an abs, pdf or html
+// arxiv.org link, or "" for anything else.
+func ArXivIDFromURL(rawURL string) string {
+	if m := arxivURLRe.FindStringSubmatch(rawURL); m != nil {
+		return m[1]
+	}
+	return ""
 }
 
 func extractArXivID(uri string) string {
@@ -143,3 +436,53 @@ type arxivAuthor struct {
 type arxivCategory struct {
 	Term string `xml:"term,attr"`
 }
+
+// ArXivConfig for ArXiv collector.
+type ArXivConfig struct {
+	Enabled    bool              `yaml:"enabled"`
+	Categories []string          `yaml:"categories"`
+	MaxResults int               `yaml:"max_results"` // page size
+	MaxPages   int               `yaml:"max_pages"`   // pages per run when catching up
+	Enrich     ArXivEnrichConfig `yaml:"enrich"`
+}
+
+// ArXivEnrichConfig configures Semantic Scholar / Papers with Code lookups.
+type ArXivEnrichConfig struct {
+	Enabled            bool   `yaml:"enabled"`
+	SemanticScholarKey string `yaml:"semantic_scholar_key"`
+	Days               int    `yaml:"days"`  // re-enrich papers published this recently
+	Limit              int    `yaml:"limit"` // max stored papers re-enriched per run
+}
+
+func init() {
+	Register(Factory[ArXivConfig]{
+		Type: SourceArXiv,
+		Defaults: func() ArXivConfig {
+			return ArXivConfig{
+				Enabled:    true,
+				Categories: []string{"cs.AI", "cs.CL", "cs.CV", "cs.LG"},
+				MaxResults: 50,
+				MaxPages:   5,
+				Enrich: ArXivEnrichConfig{
+					Enabled: true,
+					Days:    7,
+					Limit:   300,
+				},
+			}
+		},
+		Env: func(c *ArXivConfig) {
+			if v := os.Getenv("SEMANTIC_SCHOLAR_API_KEY"); v != "" {
+				c.Enrich.SemanticScholarKey = v
+			}
+		},
+		Enabled: func(c ArXivConfig) bool { return c.Enabled },
+		New: func(c ArXivConfig, deps Deps) ([]Source, error) {
+			return []Source{NewArXiv(c.Categories, c.MaxResults, c.MaxPages, deps.Store, ArXivEnrichment{
+				Enabled:            c.Enrich.Enabled,
+				SemanticScholarKey: c.Enrich.SemanticScholarKey,
+				Days:               c.Enrich.Days,
+				Limit:              c.Enrich.Limit,
+			})}, nil
+		},
+	})
+}
@@ -0,0 +1,246 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	s2BatchURL = "https://api.semanticscholar.org/graph/v1/paper/batch"
+	s2BatchMax = 500 // ids per batch request
+	pwcAPIURL  = "https://paperswithcode.com/api/v1"
+
+	// pwcRecheck is how long a paper without a Papers with Code entry is
+	// left alone before looking it up again; code often lands days later.
+	pwcRecheck = 24 * time.Hour
+)
+
+// enrichItems adds citation counts from Semantic Scholar and linked code
+// repositories from Papers with Code, then sets Score to citations plus the
+// stars of the paper's main repository. Lookup failures are logged and leave
+// the affected papers unenriched.
+func (a *ArXiv) enrichItems(ctx context.Context, items []Item) {
+	if len(items) == 0 {
+		return
+	}
+
+	for start := 0; start < len(items); start += s2BatchMax {
+		end := min(start+s2BatchMax, len(items))
+		if err := a.semanticScholar(ctx, items[start:end]); err != nil {
+			fmt.Printf("  arxiv semantic scholar error: %v\n", err)
+		}
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, 4) // concurrency limit
+	)
+	for i := range items {
+		wg.Add(1)
+		go func(item *Item) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if err := a.papersWithCode(ctx, item); err != nil {
+				fmt.Printf("  arxiv papers with code %s error: %v\n", item.ExternalID, err)
+			}
+		}(&items[i])
+	}
+	wg.Wait()
+
+	for i := range items {
+		items[i].Score = extraInt(items[i].Extra, "citations") + extraInt(items[i].Extra, "github_stars")
+	}
+}
+
+// semanticScholar fills in citation counts for a batch of papers.
+func (a *ArXiv) semanticScholar(ctx context.Context, items []Item) error {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = "ARXIV:" + item.ExternalID
+	}
+
+	body, err := json.Marshal(map[string][]string{"ids": ids})
+	if err != nil {
+		return fmt.Errorf("marshal semantic scholar request: %w", err)
+	}
+
+	reqURL := s2BatchURL + "?fields=paperId,citationCount,influentialCitationCount"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create semantic scholar request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if a.enrich.SemanticScholarKey != "" {
+		req.Header.Set("x-api-key", a.enrich.SemanticScholarKey)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch semantic scholar: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return fmt.Errorf("semantic scholar: %w", err)
+	}
+
+	// One entry per requested id, in order; unknown papers are null.
+	var papers []*s2Paper
+	if err := json.NewDecoder(resp.Body).Decode(&papers); err != nil {
+		return fmt.Errorf("decode semantic scholar: %w", err)
+	}
+
+	for i, p := range papers {
+		if i >= len(items) || p == nil {
+			continue
+		}
+		extra := ensureExtra(&items[i])
+		extra["s2_id"] = p.PaperID
+		extra["citations"] = p.CitationCount
+		extra["influential_citations"] = p.InfluentialCitationCount
+	}
+	return nil
+}
+
+// papersWithCode looks up the code repositories linked to a paper. The Papers
+// with Code paper ID is kept in Extra so later runs only refresh star counts.
+func (a *ArXiv) papersWithCode(ctx context.Context, item *Item) error {
+	pwcID, _ := item.Extra["pwc_id"].(string)
+	if pwcID == "" {
+		if !a.pwc.due(item.ExternalID) {
+			return nil
+		}
+
+		var found pwcList[pwcPaper]
+		if err := a.pwcGet(ctx, "/papers/?arxiv_id="+url.QueryEscape(item.ExternalID), &found); err != nil {
+			return err
+		}
+		if len(found.Results) == 0 {
+			a.pwc.missed(item.ExternalID)
+			return nil
+		}
+		pwcID = found.Results[0].ID
+		ensureExtra(item)["pwc_id"] = pwcID
+	}
+
+	var repos pwcList[pwcRepo]
+	if err := a.pwcGet(ctx, "/papers/"+url.PathEscape(pwcID)+"/repositories/", &repos); err != nil {
+		return err
+	}
+	if len(repos.Results) == 0 {
+		return nil
+	}
+
+	// Prefer the official implementation, then the most starred.
+	best := repos.Results[0]
+	var urls []string
+	for _, r := range repos.Results {
+		urls = append(urls, r.URL)
+		if (r.IsOfficial && !best.IsOfficial) || (r.IsOfficial == best.IsOfficial && r.Stars > best.Stars) {
+			best = r
+		}
+	}
+
+	extra := ensureExtra(item)
+	extra["code_urls"] = urls
+	extra["code_url"] = best.URL
+	extra["code_repo"] = GitHubRepoFromURL(best.URL)
+	extra["github_stars"] = best.Stars
+	extra["official_code"] = best.IsOfficial
+	extra["framework"] = best.Framework
+	return nil
+}
+
+func (a *ArXiv) pwcGet(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pwcAPIURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("create papers with code request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch papers with code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return fmt.Errorf("papers with code: %w", err)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode papers with code: %w", err)
+	}
+	return nil
+}
+
+// pwcCache remembers papers that had no Papers with Code entry.
+type pwcCache struct {
+	mu      sync.Mutex
+	checked map[string]time.Time
+}
+
+func newPWCCache() *pwcCache {
+	return &pwcCache{checked: make(map[string]time.Time)}
+}
+
+func (c *pwcCache) due(id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return time.Since(c.checked[id]) > pwcRecheck
+}
+
+func (c *pwcCache) missed(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checked[id] = time.Now()
+}
+
+func ensureExtra(item *Item) map[string]any {
+	if item.Extra == nil {
+		item.Extra = make(map[string]any)
+	}
+	return item.Extra
+}
+
+// extraInt reads a number from Extra, which holds ints for fresh items and
+// float64s for items decoded from the store.
+func extraInt(extra map[string]any, key string) int {
+	switch v := extra[key].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+type s2Paper struct {
+	PaperID                  string `json:"paperId"`
+	CitationCount            int    `json:"citationCount"`
+	InfluentialCitationCount int    `json:"influentialCitationCount"`
+}
+
+type pwcList[T any] struct {
+	Count   int `json:"count"`
+	Results []T `json:"results"`
+}
+
+type pwcPaper struct {
+	ID      string `json:"id"`
+	ArXivID string `json:"arxiv_id"`
+}
+
+type pwcRepo struct {
+	URL        string `json:"url"`
+	Stars      int    `json:"stars"`
+	Framework  string `json:"framework"`
+	IsOfficial bool   `json:"is_official"`
+}
@@ -0,0 +1,155 @@
+package source
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeArXiv serves the arXiv search API from a list of papers, honoring
+// paging and submittedDate ranges.
+type fakeArXiv struct {
+	mu     sync.Mutex
+	papers []arxivEntry // newest first
+}
+
+var submittedRe = regexp.MustCompile(`submittedDate:\[(\d{12}) TO (\d{12})\]`)
+
+func (f *fakeArXiv) add(id int, published time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.papers = append(f.papers, arxivEntry{
+		ID:        fmt.Sprintf("http://arxiv.org/abs/2609.%05dv1", id),
+		Title:     fmt.Sprintf("Paper %d", id),
+		Published: published.UTC().Truncate(time.Minute),
+	})
+	sort.Slice(f.papers, func(i, j int) bool { return f.papers[i].Published.After(f.papers[j].Published) })
+}
+
+func (f *fakeArXiv) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	q := r.URL.Query()
+	start, _ := strconv.Atoi(q.Get("start"))
+	size, _ := strconv.Atoi(q.Get("max_results"))
+
+	matching := f.papers
+	if m := submittedRe.FindStringSubmatch(q.Get("search_query")); m != nil {
+		from, _ := time.Parse("200601021504", m[1])
+		to, _ := time.Parse("200601021504", m[2])
+		matching = nil
+		for _, p := range f.papers {
+			if !p.Published.Before(from) && !p.Published.After(to) {
+				matching = append(matching, p)
+			}
+		}
+	}
+
+	feed := arxivFeed{}
+	if start < len(matching) {
+		feed.Entries = matching[start:min(start+size, len(matching))]
+	}
+	w.Header().Set("Content-Type", "application/atom+xml")
+	xml.NewEncoder(w).Encode(feed)
+}
+
+func TestArXivCatchesUpAfterPageLimit(t *testing.T) {
+	fake := &fakeArXiv{}
+	now := time.Now()
+	for i := 1; i <= 12; i++ {
+		fake.add(i, now.Add(-time.Duration(i)*time.Hour))
+	}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+	defer func(u string) { arxivAPIURL = u }(arxivAPIURL)
+	arxivAPIURL = srv.URL
+
+	// Two pages of two papers per run: a busy day takes several runs.
+	a := NewArXiv([]string{"cs.AI"}, 2, 2, nil, ArXivEnrichment{})
+	ctx := context.Background()
+
+	seen := make(map[string]bool)
+	collect := func() {
+		t.Helper()
+		items, err := a.Collect(ctx)
+		if err != nil {
+			t.Fatalf("Collect: %v", err)
+		}
+		a.cursors.commit(ctx)
+		for _, item := range items {
+			seen[item.ExternalID] = true
+		}
+	}
+
+	collect()
+	if len(seen) != 4 {
+		t.Fatalf("first run collected %d papers, want 4", len(seen))
+	}
+	if mark, _ := a.cursors.Time(ctx, "published"); mark.After(now.Add(-12 * time.Hour)) {
+		t.Fatalf("high-water mark advanced to %s with papers left unread", mark)
+	}
+
+	// New papers keep arriving while the backlog is paged through.
+	fake.add(13, now.Add(time.Minute))
+	for run := 0; run < 10; run++ {
+		collect()
+		if before, _ := a.gap(ctx); before.IsZero() {
+			break
+		}
+	}
+	if len(seen) != 13 {
+		t.Fatalf("collected %d of 13 papers", len(seen))
+	}
+
+	if before, _ := a.gap(ctx); !before.IsZero() {
+		t.Fatalf("gap still open before %s", before)
+	}
+	mark, _ := a.cursors.Time(ctx, "published")
+	if want := now.Add(time.Minute).UTC().Truncate(time.Minute); !mark.Equal(want) {
+		t.Fatalf("high-water mark = %s, want newest paper %s", mark, want)
+	}
+}
+
+func TestArXivAdvancesWhenHarvestReachesMark(t *testing.T) {
+	fake := &fakeArXiv{}
+	now := time.Now()
+	for i := 1; i <= 3; i++ {
+		fake.add(i, now.Add(-time.Duration(i)*time.Hour))
+	}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+	defer func(u string) { arxivAPIURL = u }(arxivAPIURL)
+	arxivAPIURL = srv.URL
+
+	a := NewArXiv([]string{"cs.AI"}, 2, 5, nil, ArXivEnrichment{})
+	ctx := context.Background()
+
+	items, err := a.Collect(ctx)
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("collected %d papers, want 3", len(items))
+	}
+	a.cursors.commit(ctx)
+	if before, _ := a.gap(ctx); !before.IsZero() {
+		t.Fatalf("gap recorded for a complete harvest")
+	}
+
+	items, err = a.Collect(ctx)
+	if err != nil {
+		t.Fatalf("second Collect: %v", err)
+	}
+	if len(items) != 0 {
+		t.Fatalf("second run collected %d papers, want none", len(items))
+	}
+}
@@ -0,0 +1,63 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Backfiller is implemented by sources whose upstream can be queried for a
+// past period, e.g. by a date range search.
+type Backfiller interface {
+	// Backfill returns the items published between from and to, with their
+	// current scores and, where the upstream keeps it, their History.
+	Backfill(ctx context.Context, from, to time.Time) ([]Item, error)
+}
+
+// Snapshot is a past score of an item, reconstructed by a backfill.
+type Snapshot struct {
+	At       time.Time
+	Score    int
+	Comments int
+}
+
+// CanBackfill reports whether src can collect a past period.
+func CanBackfill(src Source) bool {
+	_, ok := InstanceOf(src).Source.(Backfiller)
+	return ok
+}
+
+// Backfill collects the items src published between from and to. Items are
+// stamped with the instance name and dated as collected when published, so
+// a backfill doesn't show up as current activity.
+func Backfill(ctx context.Context, src Source, from, to time.Time) ([]Item, error) {
+	inst := InstanceOf(src)
+	b, ok := inst.Source.(Backfiller)
+	if !ok {
+		return nil, fmt.Errorf("%s: no historical API", inst.InstanceName())
+	}
+
+	items, err := b.Backfill(ctx, from, to)
+	for k := range items {
+		items[k].Instance = inst.name
+		items[k].CollectedAt = items[k].PublishedAt
+	}
+	return items, err
+}
+
+// days splits [from, to) into windows of at most a day, for upstreams that
+// cap the results of a single query.
+func days(from, to time.Time) [][2]time.Time {
+	var windows [][2]time.Time
+	for start := from; start.Before(to); start = start.Add(24 * time.Hour) {
+		windows = append(windows, [2]time.Time{start, minTime(start.Add(24*time.Hour), to)})
+	}
+	return windows
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,231 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Bluesky collects posts from Bluesky accounts and custom feeds via the
+// public AppView XRPC API. No authentication is required.
+//
+// Posts from the last 24 hours are re-emitted on every run so that their
+// like/repost counts are snapshotted and feed the velocity scorer.
+type Bluesky struct {
+	client  *http.Client
+	appView string
+	handles []string
+	feeds   []string
+}
+
+// NewBluesky creates a new Bluesky collector.
+// feeds are feed generator AT-URIs (at://did:plc:.../app.bsky.feed.generator/...).
+func NewBluesky(appViewURL string, handles, feeds []string) *Bluesky {
+	if appViewURL == "" {
+		appViewURL = "https://public.api.bsky.app"
+	}
+	return &Bluesky{
+		client:  newHTTPClient(30 * time.Second),
+		appView: strings.TrimRight(appViewURL, "/"),
+		handles: handles,
+		feeds:   feeds,
+	}
+}
+
+func (b *Bluesky) Name() SourceType { return SourceBluesky }
+
+func (b *Bluesky) Collect(ctx context.Context) ([]Item, error) {
+	var allItems []Item
+	seen := make(map[string]bool)
+
+	add := func(items []Item) {
+		for _, item := range items {
+			if !seen[item.ExternalID] {
+				seen[item.ExternalID] = true
+				allItems = append(allItems, item)
+			}
+		}
+	}
+
+	for _, handle := range b.handles {
+		params := url.Values{}
+		params.Set("actor", strings.TrimPrefix(handle, "@"))
+		params.Set("filter", "posts_no_replies")
+		params.Set("limit", "50")
+
+		items, err := b.fetchFeed(ctx, "app.bsky.feed.getAuthorFeed", params)
+		if err != nil {
+			fmt.Printf("  bluesky @%s error: %v\n", handle, err)
+			continue
+		}
+		add(items)
+	}
+
+	for _, feed := range b.feeds {
+		params := url.Values{}
+		params.Set("feed", feed)
+		params.Set("limit", "50")
+
+		items, err := b.fetchFeed(ctx, "app.bsky.feed.getFeed", params)
+		if err != nil {
+			fmt.Printf("  bluesky feed %s error: %v\n", feed, err)
+			continue
+		}
+		add(items)
+	}
+
+	return allItems, nil
+}
+
+func (b *Bluesky) fetchFeed(ctx context.Context, method string, params url.Values) ([]Item, error) {
+	reqURL := fmt.Sprintf("%s/xrpc/%s?%s", b.appView, method, params.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create bluesky request: %w", err)
+	}
+	req.Header.Set("User-Agent", "airadar/1.0")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch bluesky %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return nil, fmt.Errorf("bluesky %s: %w", method, err)
+	}
+
+	var result bskyFeedResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode bluesky %s: %w", method, err)
+	}
+
+	var items []Item
+	cutoff := time.Now().Add(-24 * time.Hour)
+
+	for _, entry := range result.Feed {
+		// Skip reposts; the original author's post is what we track.
+		if entry.Reason != nil {
+			continue
+		}
+
+		post := entry.Post
+		published := post.Record.CreatedAt
+		if published.IsZero() {
+			published = post.IndexedAt
+		}
+		if published.Before(cutoff) {
+			continue
+		}
+
+		text := strings.TrimSpace(post.Record.Text)
+		extra := map[string]any{
+			"handle":  post.Author.Handle,
+			"did":     post.Author.DID,
+			"likes":   post.LikeCount,
+			"reposts": post.RepostCount,
+			"quotes":  post.QuoteCount,
+		}
+
+		// Link cards carry the shared article, which is often the real story.
+		description := text
+		if ext := post.Embed.External; ext != nil && ext.URI != "" {
+			extra["link"] = ext.URI
+			if ext.Title != "" {
+				description = text + "\n\n" + ext.Title + " - " + ext.Description
+			}
+			if text == "" {
+				text = ext.Title
+			}
+		}
+
+		if text == "" {
+			continue
+		}
+
+		items = append(items, Item{
+			ID:          fmt.Sprintf("bluesky:%s", post.URI),
+			Source:      SourceBluesky,
+			ExternalID:  post.URI,
+			Title:       truncate(text, 280),
+			URL:         bskyPostURL(post.Author.Handle, post.URI),
+			Description: truncate(description, 500),
+			Author:      post.Author.Handle,
+			Score:       post.LikeCount + post.RepostCount,
+			Comments:    post.ReplyCount,
+			Tags:        post.Record.Tags,
+			PublishedAt: published.UTC(),
+			CollectedAt: time.Now().UTC(),
+			Extra:       extra,
+		})
+	}
+
+	return items, nil
+}
+
+// bskyPostURL converts an AT-URI (at://did/app.bsky.feed.post/rkey) into a
+// bsky.app web link.
+func bskyPostURL(handle, uri string) string {
+	rkey := uri[strings.LastIndex(uri, "/")+1:]
+	return fmt.Sprintf("https://bsky.app/profile/%s/post/%s", handle, rkey)
+}
+
+type bskyFeedResult struct {
+	Feed []struct {
+		Post   bskyPost        `json:"post"`
+		Reason json.RawMessage `json:"reason,omitempty"`
+	} `json:"feed"`
+	Cursor string `json:"cursor"`
+}
+
+type bskyPost struct {
+	URI    string `json:"uri"`
+	Author struct {
+		DID         string `json:"did"`
+		Handle      string `json:"handle"`
+		DisplayName string `json:"displayName"`
+	} `json:"author"`
+	Record struct {
+		Text      string    `json:"text"`
+		CreatedAt time.Time `json:"createdAt"`
+		Tags      []string  `json:"tags"`
+	} `json:"record"`
+	Embed struct {
+		External *struct {
+			URI         string `json:"uri"`
+			Title       string `json:"title"`
+			Description string `json:"description"`
+		} `json:"external"`
+	} `json:"embed"`
+	ReplyCount  int       `json:"replyCount"`
+	RepostCount int       `json:"repostCount"`
+	LikeCount   int       `json:"likeCount"`
+	QuoteCount  int       `json:"quoteCount"`
+	IndexedAt   time.Time `json:"indexedAt"`
+}
+
+// BlueskyConfig for Bluesky collector.
+type BlueskyConfig struct {
+	Enabled    bool     `yaml:"enabled"`
+	AppViewURL string   `yaml:"appview_url"`
+	Handles    []string `yaml:"handles"`
+	Feeds      []string `yaml:"feeds"` // feed generator AT-URIs
+}
+
+func init() {
+	Register(Factory[BlueskyConfig]{
+		Type:    SourceBluesky,
+		Aliases: []string{"bsky"},
+		Defaults: func() BlueskyConfig {
+			return BlueskyConfig{Enabled: false, AppViewURL: "https://public.api.bsky.app"}
+		},
+		Enabled: func(c BlueskyConfig) bool { return c.Enabled },
+		New: func(c BlueskyConfig, deps Deps) ([]Source, error) {
+			return []Source{NewBluesky(c.AppViewURL, c.Handles, c.Feeds)}, nil
+		},
+	})
+}
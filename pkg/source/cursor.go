@@ -0,0 +1,141 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CursorStore persists collection cursors, keyed by source instance and a
+// key chosen by the collector.
+type CursorStore interface {
+	GetCursor(ctx context.Context, instance, key string) (string, error)
+	SetCursor(ctx context.Context, instance, key, value string) error
+}
+
+// Cursors hold the incremental state of one source instance: high-water
+// marks, since IDs and HTTP validators that let the next Collect continue
+// where the last one stopped instead of refetching a fixed window. Without a
+// store they only last as long as the process.
+//
+// Cursors set during a Collect are staged until its items are stored: the
+// instance commits them afterwards, and the next Collect discards them if
+// storing failed, so the items are fetched again. CollectAndStore keeps
+// collections of an instance from discarding each other's.
+type Cursors struct {
+	store    CursorStore
+	instance string
+	since    time.Time // set by Rewind
+
+	mu      sync.Mutex
+	memory  map[string]string // committed cursors when there is no store
+	pending map[string]string // staged by the last Collect
+}
+
+func newCursors(store CursorStore, instance string) *Cursors {
+	return &Cursors{store: store, instance: instance, memory: make(map[string]string), pending: make(map[string]string)}
+}
+
+// cursorUser is implemented by collectors that keep cursors. The registry
+// hands them the cursors of their instance.
+type cursorUser interface {
+	setCursors(c *Cursors)
+}
+
+// Get returns the cursor stored under key, or "" if there is none or the
+// cursors were rewound.
+func (c *Cursors) Get(ctx context.Context, key string) (string, error) {
+	if !c.since.IsZero() {
+		return "", nil
+	}
+	return c.get(ctx, key)
+}
+
+func (c *Cursors) get(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	if v, ok := c.pending[key]; ok {
+		c.mu.Unlock()
+		return v, nil
+	}
+	if c.store == nil {
+		defer c.mu.Unlock()
+		return c.memory[key], nil
+	}
+	c.mu.Unlock()
+	return c.store.GetCursor(ctx, c.instance, key)
+}
+
+// Set stages a cursor. Get sees it right away; it is stored when the items
+// of the current Collect are.
+func (c *Cursors) Set(ctx context.Context, key, value string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pending[key] = value
+	return nil
+}
+
+// commit stores the staged cursors. Cursors that fail to store stay staged.
+func (c *Cursors) commit(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, value := range c.pending {
+		if c.store == nil {
+			c.memory[key] = value
+		} else if err := c.store.SetCursor(ctx, c.instance, key, value); err != nil {
+			return fmt.Errorf("store cursor %s: %w", key, err)
+		}
+		delete(c.pending, key)
+	}
+	return nil
+}
+
+// discard drops the cursors staged by a Collect whose items weren't stored.
+func (c *Cursors) discard() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.pending)
+}
+
+// Time returns the high-water mark stored under key, the rewind time if the
+// cursors were rewound, or the zero time.
+func (c *Cursors) Time(ctx context.Context, key string) (time.Time, error) {
+	if !c.since.IsZero() {
+		return c.since, nil
+	}
+	v, err := c.get(ctx, key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parseCursorTime(v), nil
+}
+
+// parseCursorTime parses a time cursor. Empty and unreadable cursors are the
+// zero time, so collection starts over.
+func parseCursorTime(v string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// Advance moves the high-water mark under key forward to t. Earlier times
+// are ignored, so a rewound run doesn't lose the newer mark.
+func (c *Cursors) Advance(ctx context.Context, key string, t time.Time) error {
+	if t.IsZero() {
+		return nil
+	}
+	v, err := c.get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if prev, err := time.Parse(time.RFC3339Nano, v); err == nil && !t.After(prev) {
+		return nil
+	}
+	return c.Set(ctx, key, t.UTC().Format(time.RFC3339Nano))
+}
+
+// Since returns the rewind time, or the zero time.
+func (c *Cursors) Since() time.Time { return c.since }
@@ -0,0 +1,153 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// mapCursorStore is a CursorStore in a map.
+type mapCursorStore struct {
+	values map[string]string
+	fail   error
+}
+
+func (m *mapCursorStore) GetCursor(_ context.Context, instance, key string) (string, error) {
+	return m.values[instance+"/"+key], nil
+}
+
+func (m *mapCursorStore) SetCursor(_ context.Context, instance, key, value string) error {
+	if m.fail != nil {
+		return m.fail
+	}
+	m.values[instance+"/"+key] = value
+	return nil
+}
+
+func TestCursorsAdvance(t *testing.T) {
+	ctx := context.Background()
+	c := newCursors(nil, "")
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		advance time.Time
+		want    time.Time
+	}{
+		{time.Time{}, time.Time{}}, // zero is ignored
+		{t0, t0},                   // first mark
+		{t0.Add(-time.Hour), t0},   // earlier times don't move it back
+		{t0.Add(time.Minute), t0.Add(time.Minute)},
+		{t0.Add(time.Minute), t0.Add(time.Minute)},
+	}
+	for i, s := range steps {
+		if err := c.Advance(ctx, "published", s.advance); err != nil {
+			t.Fatalf("step %d: Advance: %v", i, err)
+		}
+		if got, _ := c.Time(ctx, "published"); !got.Equal(s.want) {
+			t.Fatalf("step %d: mark = %s, want %s", i, got, s.want)
+		}
+	}
+
+	// An unreadable cursor starts over.
+	c.Set(ctx, "published", "yesterday")
+	if got, _ := c.Time(ctx, "published"); !got.IsZero() {
+		t.Fatalf("unreadable mark = %s, want zero", got)
+	}
+	c.Advance(ctx, "published", t0)
+	if got, _ := c.Time(ctx, "published"); !got.Equal(t0) {
+		t.Fatalf("mark = %s after replacing an unreadable one, want %s", got, t0)
+	}
+}
+
+func TestCursorsRewind(t *testing.T) {
+	ctx := context.Background()
+	store := &mapCursorStore{values: map[string]string{}}
+	inst := &Instance{Source: &probeSource{}, name: "probe"}
+	inst.cursors = newCursors(store, "probe")
+	c := inst.cursors
+
+	mark := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	c.Advance(ctx, "published", mark)
+	c.Set(ctx, "etag", `"v1"`)
+	c.commit(ctx)
+
+	since := mark.Add(-72 * time.Hour)
+	inst.Rewind(since)
+	if got, _ := c.Time(ctx, "published"); !got.Equal(since) {
+		t.Fatalf("rewound mark = %s, want %s", got, since)
+	}
+	if got, _ := c.Get(ctx, "etag"); got != "" {
+		t.Fatalf("rewound etag = %q, want none", got)
+	}
+	if c.Since() != since {
+		t.Fatalf("Since = %s", c.Since())
+	}
+
+	// The rewound run doesn't lose the newer mark, but later ones still advance it.
+	c.Advance(ctx, "published", since.Add(time.Hour))
+	c.commit(ctx)
+	if got := store.values["probe/published"]; got != mark.Format(time.RFC3339Nano) {
+		t.Fatalf("stored mark = %s, want %s", got, mark)
+	}
+	c.Advance(ctx, "published", mark.Add(time.Hour))
+	c.commit(ctx)
+	if got := store.values["probe/published"]; got != mark.Add(time.Hour).Format(time.RFC3339Nano) {
+		t.Fatalf("stored mark = %s, want %s", got, mark.Add(time.Hour))
+	}
+}
+
+func TestCursorsStageUntilCommit(t *testing.T) {
+	ctx := context.Background()
+	store := &mapCursorStore{values: map[string]string{"probe/since_id": "100"}}
+	inst := &Instance{Source: &probeSource{}, name: "probe"}
+	inst.cursors = newCursors(store, "probe")
+	c := inst.cursors
+
+	// A Collect whose items fail to store: its cursors are seen during the
+	// run, but never stored, and the next Collect starts from the old ones.
+	inst.Collect(ctx)
+	c.Set(ctx, "since_id", "200")
+	if got, _ := c.Get(ctx, "since_id"); got != "200" {
+		t.Fatalf("staged since_id = %q, want 200", got)
+	}
+	if got := store.values["probe/since_id"]; got != "100" {
+		t.Fatalf("stored since_id = %q before commit", got)
+	}
+	inst.Collect(ctx)
+	if got, _ := c.Get(ctx, "since_id"); got != "100" {
+		t.Fatalf("since_id = %q after an uncommitted run, want 100", got)
+	}
+
+	// Stored items commit their cursors.
+	c.Set(ctx, "since_id", "300")
+	if err := inst.Commit(ctx); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if got := store.values["probe/since_id"]; got != "300" {
+		t.Fatalf("stored since_id = %q, want 300", got)
+	}
+
+	// Cursors that fail to store stay staged for the next commit.
+	store.fail = errors.New("disk full")
+	c.Set(ctx, "since_id", "400")
+	if err := inst.Commit(ctx); !errors.Is(err, store.fail) {
+		t.Fatalf("Commit = %v, want the store error", err)
+	}
+	store.fail = nil
+	if err := inst.Commit(ctx); err != nil || store.values["probe/since_id"] != "400" {
+		t.Fatalf("retried Commit = %v, stored %q", err, store.values["probe/since_id"])
+	}
+}
+
+func TestCursorsWithoutStore(t *testing.T) {
+	ctx := context.Background()
+	c := newCursors(nil, "")
+	c.Set(ctx, "etag", `"v1"`)
+	c.commit(ctx)
+	c.Set(ctx, "etag", `"v2"`)
+	c.discard()
+	if got, _ := c.Get(ctx, "etag"); got != `"v1"` {
+		t.Fatalf("etag = %q, want the committed one", got)
+	}
+}
@@ -0,0 +1,179 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const devtoAPIURL = "https://dev.to/api"
+
+// DevTo collects popular AI articles from Dev.to.
+type DevTo struct {
+	client  *http.Client
+	tags    []string
+	topDays int
+	perPage int
+}
+
+// NewDevTo creates a new Dev.to collector.
+// For each tag it fetches the most reacted-to articles of the last topDays.
+func NewDevTo(tags []string, topDays, perPage int) *DevTo {
+	if len(tags) == 0 {
+		tags = []string{"ai", "llm", "machinelearning"}
+	}
+	if topDays <= 0 {
+		topDays = 1
+	}
+	if perPage <= 0 {
+		perPage = 30
+	}
+	return &DevTo{
+		client:  newHTTPClient(30 * time.Second),
+		tags:    tags,
+		topDays: topDays,
+		perPage: perPage,
+	}
+}
+
+func (d *DevTo) Name() SourceType { return SourceDevTo }
+
+func (d *DevTo) Collect(ctx context.Context) ([]Item, error) {
+	var (
+		items   []Item
+		seen    = make(map[int]bool)
+		lastErr error
+		ok      int
+	)
+
+	for _, tag := range d.tags {
+		articles, err := d.fetchTag(ctx, tag)
+		if err != nil {
+			fmt.Printf("  devto tag %s error: %v\n", tag, err)
+			lastErr = err
+			continue
+		}
+		ok++
+
+		for _, a := range articles {
+			if seen[a.ID] {
+				continue
+			}
+			seen[a.ID] = true
+			items = append(items, a.toItem())
+		}
+	}
+
+	if ok == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return items, nil
+}
+
+func (d *DevTo) fetchTag(ctx context.Context, tag string) ([]devtoArticle, error) {
+	params := url.Values{}
+	params.Set("tag", tag)
+	params.Set("top", strconv.Itoa(d.topDays))
+	params.Set("per_page", strconv.Itoa(d.perPage))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, devtoAPIURL+"/articles?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create devto request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.forem.api-v1+json")
+	req.Header.Set("User-Agent", "airadar/1.0")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch devto: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return nil, fmt.Errorf("devto: %w", err)
+	}
+
+	var articles []devtoArticle
+	if err := json.NewDecoder(resp.Body).Decode(&articles); err != nil {
+		return nil, fmt.Errorf("decode devto: %w", err)
+	}
+	return articles, nil
+}
+
+type devtoArticle struct {
+	ID            int       `json:"id"`
+	Title         string    `json:"title"`
+	Description   string    `json:"description"`
+	URL           string    `json:"url"`
+	CanonicalURL  string    `json:"canonical_url"`
+	Reactions     int       `json:"public_reactions_count"`
+	CommentsCount int       `json:"comments_count"`
+	ReadingTime   int       `json:"reading_time_minutes"`
+	TagList       []string  `json:"tag_list"`
+	PublishedAt   time.Time `json:"published_at"`
+	User          struct {
+		Username string `json:"username"`
+		Name     string `json:"name"`
+	} `json:"user"`
+}
+
+func (a devtoArticle) toItem() Item {
+	published := a.PublishedAt
+	if published.IsZero() {
+		published = time.Now()
+	}
+
+	extra := map[string]any{
+		"reading_time": a.ReadingTime,
+	}
+	// Cross-posts point at the original; keep it for correlation.
+	if a.CanonicalURL != "" && a.CanonicalURL != a.URL {
+		extra["canonical_url"] = a.CanonicalURL
+	}
+
+	return Item{
+		ID:          fmt.Sprintf("devto:%d", a.ID),
+		Source:      SourceDevTo,
+		ExternalID:  strconv.Itoa(a.ID),
+		Title:       a.Title,
+		URL:         a.URL,
+		Description: truncate(a.Description, 500),
+		Author:      a.User.Username,
+		Score:       a.Reactions,
+		Comments:    a.CommentsCount,
+		Tags:        a.TagList,
+		PublishedAt: published.UTC(),
+		CollectedAt: time.Now().UTC(),
+		Extra:       extra,
+	}
+}
+
+// DevToConfig for Dev.to collector.
+type DevToConfig struct {
+	Enabled bool     `yaml:"enabled"`
+	Tags    []string `yaml:"tags"`
+	TopDays int      `yaml:"top_days"` // most reacted-to articles of the last N days
+	PerPage int      `yaml:"per_page"` // articles per tag
+}
+
+func init() {
+	Register(Factory[DevToConfig]{
+		Type: SourceDevTo,
+		Defaults: func() DevToConfig {
+			return DevToConfig{
+				Enabled: true,
+				Tags:    []string{"ai", "llm", "machinelearning"},
+				TopDays: 1,
+				PerPage: 30,
+			}
+		},
+		Enabled: func(c DevToConfig) bool { return c.Enabled },
+		New: func(c DevToConfig, deps Deps) ([]Source, error) {
+			return []Source{NewDevTo(c.Tags, c.TopDays, c.PerPage)}, nil
+		},
+	})
+}
@@ -0,0 +1,273 @@
+package source
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ExecSpec declares an external collector process.
+type ExecSpec struct {
+	Name    string
+	Type    SourceType // defaults to Name
+	Command string
+	Args    []string
+	Env     map[string]string // added to the inherited environment
+	Dir     string
+	Timeout time.Duration
+}
+
+// Exec runs an external command on each collection and reads items from its
+// stdout as newline-delimited JSON, one Item object per line (the same shape
+// the HTTP API returns). Lines must carry a title and an external_id or id;
+// source, id and collected_at are filled in by airadar. The process's stderr
+// is forwarded to the collection log.
+type Exec struct {
+	spec ExecSpec
+}
+
+// NewExec creates a new exec plugin collector.
+func NewExec(spec ExecSpec) *Exec {
+	if spec.Type == "" {
+		spec.Type = SourceType(spec.Name)
+	}
+	if spec.Timeout <= 0 {
+		spec.Timeout = 2 * time.Minute
+	}
+	return &Exec{spec: spec}
+}
+
+func (e *Exec) Name() SourceType { return e.spec.Type }
+
+// InstanceName is the configured plugin name.
+func (e *Exec) InstanceName() string { return e.spec.Name }
+
+func (e *Exec) Collect(ctx context.Context) ([]Item, error) {
+	if e.spec.Command == "" {
+		return nil, fmt.Errorf("exec %s: no command configured", e.spec.Name)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, e.spec.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, e.spec.Command, e.spec.Args...)
+	cmd.Dir = e.spec.Dir
+	cmd.Env = os.Environ()
+	for k, v := range e.spec.Env {
+		cmd.Env = append(cmd.Env, k+"="+os.ExpandEnv(v))
+	}
+	// Don't hang on pipes held open by grandchildren after a timeout kill.
+	cmd.WaitDelay = 5 * time.Second
+
+	stderr := &logWriter{prefix: fmt.Sprintf("  exec %s: ", e.spec.Name)}
+	cmd.Stderr = stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("exec %s: stdout pipe: %w", e.spec.Name, err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("exec %s: start: %w", e.spec.Name, err)
+	}
+
+	var (
+		items   []Item
+		invalid int
+		lineNo  int
+	)
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		lineNo++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		item, err := e.parseLine(line)
+		if err != nil {
+			invalid++
+			fmt.Printf("  exec %s: line %d: %v\n", e.spec.Name, lineNo, err)
+			continue
+		}
+		items = append(items, item)
+	}
+	scanErr := scanner.Err()
+
+	waitErr := cmd.Wait()
+	stderr.Flush()
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("exec %s: timed out after %s", e.spec.Name, e.spec.Timeout)
+	}
+	if waitErr != nil {
+		return nil, fmt.Errorf("exec %s: %w", e.spec.Name, waitErr)
+	}
+	if scanErr != nil {
+		return nil, fmt.Errorf("exec %s: read stdout: %w", e.spec.Name, scanErr)
+	}
+
+	if invalid > 0 {
+		fmt.Printf("  exec %s: skipped %d invalid lines\n", e.spec.Name, invalid)
+	}
+	return items, nil
+}
+
+// parseLine decodes and validates one JSONL record.
+func (e *Exec) parseLine(line []byte) (Item, error) {
+	var item Item
+	if err := json.Unmarshal(line, &item); err != nil {
+		return Item{}, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	item.Title = strings.TrimSpace(item.Title)
+	if item.Title == "" {
+		return Item{}, fmt.Errorf("missing title")
+	}
+
+	prefix := string(e.spec.Type) + ":"
+	if item.ExternalID == "" {
+		item.ExternalID = strings.TrimPrefix(item.ID, prefix)
+	}
+	if item.ExternalID == "" {
+		return Item{}, fmt.Errorf("missing external_id or id")
+	}
+
+	item.ID = prefix + item.ExternalID
+	item.Source = e.spec.Type
+	item.Description = truncate(item.Description, 500)
+	item.CollectedAt = time.Now().UTC()
+	if item.PublishedAt.IsZero() {
+		item.PublishedAt = item.CollectedAt
+	}
+	item.PublishedAt = item.PublishedAt.UTC()
+	return item, nil
+}
+
+// logWriter forwards complete lines to stdout with a prefix, matching how
+// collectors report per-feed errors.
+type logWriter struct {
+	prefix string
+	mu     sync.Mutex
+	buf    []byte
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		fmt.Printf("%s%s\n", w.prefix, bytes.TrimRight(w.buf[:i], "\r"))
+		w.buf = w.buf[i+1:]
+	}
+
+	// Guard against a plugin writing one enormous line.
+	if len(w.buf) > 64*1024 {
+		fmt.Printf("%s%s...\n", w.prefix, w.buf[:64*1024])
+		w.buf = w.buf[:0]
+	}
+	return len(p), nil
+}
+
+// Flush writes any trailing partial line.
+func (w *logWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) > 0 {
+		fmt.Printf("%s%s\n", w.prefix, w.buf)
+		w.buf = w.buf[:0]
+	}
+}
+
+// ExecConfig declares an external collector process that prints items as JSONL.
+type ExecConfig struct {
+	Name    string            `yaml:"name"`
+	Type    string            `yaml:"type"` // source type stored on items (default: name)
+	Enabled bool              `yaml:"enabled"`
+	Command string            `yaml:"command"`
+	Args    []string          `yaml:"args"`
+	Env     map[string]string `yaml:"env"` // values may use ${ENV_VAR}
+	Dir     string            `yaml:"dir"`
+	Timeout string            `yaml:"timeout"`
+}
+
+// ParseTimeout returns the plugin timeout as time.Duration, or 0 for the
+// default when none is set.
+func (e ExecConfig) ParseTimeout() (time.Duration, error) {
+	if e.Timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(e.Timeout)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q", e.Timeout)
+	}
+	return d, nil
+}
+
+// SourceExec is the registry type of the exec config section. Its sources
+// carry the type declared by each plugin.
+const SourceExec SourceType = "exec"
+
+func init() {
+	Register(Factory[[]ExecConfig]{
+		Type: SourceExec,
+		Enabled: func(plugins []ExecConfig) bool {
+			for _, p := range plugins {
+				if p.Enabled {
+					return true
+				}
+			}
+			return false
+		},
+		New: func(plugins []ExecConfig, deps Deps) ([]Source, error) {
+			var (
+				sources []Source
+				errs    []error
+			)
+			for _, p := range plugins {
+				if !p.Enabled {
+					continue
+				}
+				typ, err := customType(p.Name, p.Type)
+				if err == nil && p.Command == "" {
+					err = fmt.Errorf("%s: missing command", p.Name)
+				}
+				var timeout time.Duration
+				if err == nil {
+					if timeout, err = p.ParseTimeout(); err != nil {
+						err = fmt.Errorf("%s: %w", p.Name, err)
+					}
+				}
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				sources = append(sources, NewExec(ExecSpec{
+					Name:    p.Name,
+					Type:    typ,
+					Command: p.Command,
+					Args:    p.Args,
+					Env:     p.Env,
+					Dir:     p.Dir,
+					Timeout: timeout,
+				}))
+			}
+			return sources, errors.Join(errs...)
+		},
+	})
+}
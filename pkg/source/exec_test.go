@@ -0,0 +1,231 @@
+package source
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExecValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr string // "" = builds
+	}{
+		{
+			name: "valid",
+			doc:  "exec:\n  - {name: scraper, enabled: true, command: ./scrape}",
+		},
+		{
+			name:    "without name",
+			doc:     "exec:\n  - {enabled: true, command: ./scrape}",
+			wantErr: "missing name",
+		},
+		{
+			name:    "without command",
+			doc:     "exec:\n  - {name: scraper, enabled: true}",
+			wantErr: "scraper: missing command",
+		},
+		{
+			name:    "with a built-in type",
+			doc:     "exec:\n  - {name: scraper, type: github, enabled: true, command: ./scrape}",
+			wantErr: `type "github" is taken by the github source`,
+		},
+		{
+			name:    "with an invalid timeout",
+			doc:     "exec:\n  - {name: scraper, enabled: true, command: ./scrape, timeout: 5}",
+			wantErr: `scraper: invalid timeout "5"`,
+		},
+		{
+			name:    "with a negative timeout",
+			doc:     "exec:\n  - {name: scraper, enabled: true, command: ./scrape, timeout: -1m}",
+			wantErr: `scraper: invalid timeout "-1m"`,
+		},
+		{
+			name: "with a timeout",
+			doc:  "exec:\n  - {name: scraper, enabled: true, command: ./scrape, timeout: 30s}",
+		},
+		{
+			name: "disabled entries are not validated",
+			doc:  "exec:\n  - {enabled: false}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildFromYAML(t, tt.doc)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Build: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Build error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// captureStdout returns what fn prints, where collectors log.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	out := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		out <- string(b)
+	}()
+	fn()
+	w.Close()
+	return <-out
+}
+
+// shellExec returns an exec source running script with sh.
+func shellExec(t *testing.T, script string, timeout time.Duration) *Exec {
+	t.Helper()
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	return NewExec(ExecSpec{Name: "scraper", Command: sh, Args: []string{"-c", script}, Timeout: timeout,
+		Env: map[string]string{"SCRAPER_GREETING": "hello ${USER_SUFFIX}"}})
+}
+
+func TestExecParsesJSONL(t *testing.T) {
+	t.Setenv("USER_SUFFIX", "world")
+	e := shellExec(t, `
+echo '{"external_id": "1", "title": " First ", "url": "https://example.com/1", "score": 10, "published_at": "2026-01-02T15:04:05+02:00", "tags": ["llm"]}'
+echo ''
+echo '{"id": "scraper:2", "title": "Second"}'
+echo '{"id": "3", "title": "'"$SCRAPER_GREETING"'"}'
+echo 'not json'
+echo '{"external_id": "5"}'
+echo '{"title": "no id"}'
+echo 'progress: done' >&2
+`, 0)
+
+	var items []Item
+	var err error
+	out := captureStdout(t, func() { items, err = e.Collect(context.Background()) })
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+
+	if len(items) != 3 {
+		t.Fatalf("parsed %d items, want 3", len(items))
+	}
+	first := items[0]
+	if first.ID != "scraper:1" || first.Source != "scraper" || first.Title != "First" || first.Score != 10 ||
+		first.URL != "https://example.com/1" || len(first.Tags) != 1 {
+		t.Errorf("first item = %+v", first)
+	}
+	if want := time.Date(2026, 1, 2, 13, 4, 5, 0, time.UTC); !first.PublishedAt.Equal(want) || first.PublishedAt.Location() != time.UTC {
+		t.Errorf("published = %s, want %s", first.PublishedAt, want)
+	}
+	if items[1].ID != "scraper:2" || items[1].ExternalID != "2" || items[1].PublishedAt.IsZero() {
+		t.Errorf("second item = %+v", items[1])
+	}
+	if items[2].Title != "hello world" {
+		t.Errorf("third item title = %q, want the expanded env var", items[2].Title)
+	}
+
+	for _, want := range []string{
+		"exec scraper: line 5: invalid JSON",
+		"exec scraper: line 6: missing title",
+		"exec scraper: line 7: missing external_id or id",
+		"exec scraper: skipped 3 invalid lines",
+		"exec scraper: progress: done",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log misses %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestExecFailures(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		timeout time.Duration
+		wantErr string
+		wantLog string
+	}{
+		{
+			name:    "non-zero exit",
+			script:  `echo '{"id": "1", "title": "partial"}'; echo 'login failed' >&2; exit 3`,
+			wantErr: "exec scraper: exit status 3",
+			wantLog: "exec scraper: login failed",
+		},
+		{
+			name:    "unterminated stderr",
+			script:  `printf 'no newline' >&2; exit 1`,
+			wantErr: "exit status 1",
+			wantLog: "exec scraper: no newline",
+		},
+		{
+			name:    "timeout",
+			script:  `echo '{"id": "1", "title": "slow"}'; exec sleep 10`,
+			timeout: 200 * time.Millisecond,
+			wantErr: "exec scraper: timed out after 200ms",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := shellExec(t, tt.script, tt.timeout)
+
+			var (
+				items []Item
+				err   error
+			)
+			start := time.Now()
+			out := captureStdout(t, func() { items, err = e.Collect(context.Background()) })
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Collect = %v, want %q", err, tt.wantErr)
+			}
+			if items != nil {
+				t.Errorf("failed run returned %d items", len(items))
+			}
+			if !strings.Contains(out, tt.wantLog) {
+				t.Errorf("log misses %q:\n%s", tt.wantLog, out)
+			}
+			if d := time.Since(start); d > 5*time.Second {
+				t.Errorf("Collect took %s", d)
+			}
+		})
+	}
+}
+
+func TestExecParseTimeout(t *testing.T) {
+	tests := []struct {
+		timeout string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"90s", 90 * time.Second, false},
+		{"2m", 2 * time.Minute, false},
+		{"2", 0, true},
+		{"soon", 0, true},
+		{"0s", 0, true},
+		{"-5s", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ExecConfig{Timeout: tt.timeout}.ParseTimeout()
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("ParseTimeout(%q) = %s, %v", tt.timeout, got, err)
+		}
+	}
+}
@@ -48,6 +48,25 @@ func NewFilter(extraKeywords, excludeKeywords []string) *Filter {
 	return &Filter{keywords: keywords, exclude: exclude}
 }
 
+// With returns a copy of f with additional keywords and exclusions. A nil
+// filter starts from the defaults.
+func (f *Filter) With(extraKeywords, excludeKeywords []string) *Filter {
+	if f == nil {
+		return NewFilter(extraKeywords, excludeKeywords)
+	}
+	g := &Filter{
+		keywords: append([]string(nil), f.keywords...),
+		exclude:  append([]string(nil), f.exclude...),
+	}
+	for _, kw := range extraKeywords {
+		g.keywords = append(g.keywords, strings.ToLower(kw))
+	}
+	for _, kw := range excludeKeywords {
+		g.exclude = append(g.exclude, strings.ToLower(kw))
+	}
+	return g
+}
+
 // MatchesAI returns true if text contains AI-related keywords.
 func (f *Filter) MatchesAI(text string) bool {
 	lower := strings.ToLower(text)
@@ -1,38 +1,193 @@
 package source
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"os"
+	"regexp"
+	"strings"
 	"time"
 )
 
+// ghTopicQuery restricts GitHub searches to AI-related repositories.
+const ghTopicQuery = "(topic:ai OR topic:llm OR topic:machine-learning OR topic:deep-learning OR topic:gpt OR topic:transformer OR topic:chatgpt)"
+
+// ghGraphQLBatch is the number of repositories fetched per GraphQL query.
+const ghGraphQLBatch = 50
+
+// ItemLister reads back items a collector instance stored on previous runs.
+type ItemLister interface {
+	ListItemsByInstance(ctx context.Context, instance string, since time.Time, limit int) ([]Item, error)
+}
+
+// GitHub discovery modes.
+const (
+	GitHubModeSearch   = "search"   // topic-tagged repos via the search API
+	GitHubModeTrending = "trending" // github.com/trending, AI filter applied
+	GitHubModeBoth     = "both"
+)
+
+// GitHubOptions configures the GitHub collector.
+type GitHubOptions struct {
+	Token      string
+	Mode       string   // GitHubModeSearch (default), GitHubModeTrending or GitHubModeBoth
+	Languages  []string // trending page languages, e.g. "python" (default: all languages)
+	Since      []string // trending periods: "daily", "weekly", "monthly" (default: daily)
+	TrackDays  int      // keep re-polling discovered repos for this long (default: 14)
+	TrackLimit int      // max stored repos re-polled per run (default: 500)
+
+	// Releases is a watchlist of "owner/repo" whose releases (or tags, for
+	// repos without releases) from the last ReleaseDays become items.
+	Releases    []string
+	ReleaseDays int // default: 7
+}
+
 // GitHub collects trending AI repositories from GitHub.
+//
+// Discovery runs the search API (new repos by stars, and popular repos by
+// most recent activity, which includes starring), the github.com/trending
+// pages, or both. Repositories discovered in the last trackDays are then
+// re-polled, so their star counts keep getting snapshotted for the velocity
+// scorer after they drop out of discovery. Releases of watched repositories
+// are collected independently of the discovery mode.
 type GitHub struct {
-	client *http.Client
-	token  string
+	client      *http.Client
+	token       string
+	mode        string
+	languages   []string
+	since       []string
+	filter      *Filter
+	store       ItemLister // optional, nil disables re-polling
+	instance    string     // whose stored repositories to re-poll
+	trackDays   int
+	trackLimit  int
+	watch       []string
+	releaseDays int
+	readmes     *readmeCache
 }
 
 // NewGitHub creates a new GitHub collector.
-func NewGitHub(token string) *GitHub {
+func NewGitHub(opts GitHubOptions, filter *Filter, store ItemLister) *GitHub {
+	if opts.Mode == "" {
+		opts.Mode = GitHubModeSearch
+	}
+	if len(opts.Since) == 0 {
+		opts.Since = []string{"daily"}
+	}
+	if opts.TrackDays <= 0 {
+		opts.TrackDays = 14
+	}
+	if opts.TrackLimit <= 0 {
+		opts.TrackLimit = 500
+	}
+	if opts.ReleaseDays <= 0 {
+		opts.ReleaseDays = 7
+	}
 	return &GitHub{
-		client: &http.Client{Timeout: 30 * time.Second},
-		token:  token,
+		client:      newHTTPClient(30 * time.Second),
+		token:       opts.Token,
+		mode:        opts.Mode,
+		languages:   opts.Languages,
+		since:       opts.Since,
+		filter:      filter,
+		store:       store,
+		trackDays:   opts.TrackDays,
+		trackLimit:  opts.TrackLimit,
+		watch:       opts.Releases,
+		releaseDays: opts.ReleaseDays,
+		readmes:     newReadmeCache(),
+		instance:    string(SourceGitHub),
 	}
 }
 
 func (g *GitHub) Name() SourceType { return SourceGitHub }
 
+func (g *GitHub) setInstance(name string) { g.instance = name }
+
 func (g *GitHub) Collect(ctx context.Context) ([]Item, error) {
+	now := time.Now().UTC()
+
+	var items []Item
+	index := make(map[string]int)
+	seen := make(map[string]bool)
+
+	if g.mode != GitHubModeTrending {
+		repos, err := g.discover(ctx, now)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range repos {
+			if seen[repo.FullName] {
+				continue
+			}
+			seen[repo.FullName] = true
+			index[repo.FullName] = len(items)
+			items = append(items, repo.toItem(now))
+		}
+	}
+
+	if g.mode == GitHubModeTrending || g.mode == GitHubModeBoth {
+		trending, err := g.trending(ctx)
+		if err != nil {
+			if g.mode == GitHubModeTrending {
+				return nil, err
+			}
+			fmt.Printf("  github trending error: %v\n", err)
+		}
+		for _, repo := range trending {
+			if i, ok := index[repo.FullName]; ok {
+				// Keep the richer search result, add the trending stars.
+				repo.addTo(&items[i])
+				continue
+			}
+			seen[repo.FullName] = true
+			items = append(items, repo.toItem(now))
+		}
+	}
+
+	tracked, err := g.repoll(ctx, seen)
+	if err != nil {
+		fmt.Printf("  github re-poll error: %v\n", err)
+	}
+	items = append(items, tracked...)
+
+	if len(g.watch) > 0 {
+		releases, err := g.releases(ctx)
+		if err != nil {
+			fmt.Printf("  github releases error: %v\n", err)
+		}
+		items = append(items, releases...)
+	}
+
+	return items, nil
+}
+
+// discover runs the search API passes.
+func (g *GitHub) discover(ctx context.Context, now time.Time) ([]ghRepo, error) {
 	// Search for AI-related repos created in the last 7 days, sorted by stars.
-	since := time.Now().AddDate(0, 0, -7).Format("2006-01-02")
-	query := fmt.Sprintf("created:>%s (topic:ai OR topic:llm OR topic:machine-learning OR topic:deep-learning OR topic:gpt OR topic:transformer OR topic:chatgpt)", since)
+	since := now.AddDate(0, 0, -7).Format("2006-01-02")
+	repos, err := g.search(ctx, fmt.Sprintf("created:>%s %s", since, ghTopicQuery), "stars")
+	if err != nil {
+		return nil, err
+	}
+
+	// Established repos that were recently starred: updated_at moves on
+	// every new star, so sorting by "updated" surfaces sudden activity.
+	starred, err := g.search(ctx, fmt.Sprintf("stars:>=200 %s", ghTopicQuery), "updated")
+	if err != nil {
+		fmt.Printf("  github recently starred error: %v\n", err)
+	}
+	return append(repos, starred...), nil
+}
 
+func (g *GitHub) search(ctx context.Context, query, sort string) ([]ghRepo, error) {
 	params := url.Values{}
 	params.Set("q", query)
-	params.Set("sort", "stars")
+	params.Set("sort", sort)
 	params.Set("order", "desc")
 	params.Set("per_page", "50")
 
@@ -53,46 +208,185 @@ func (g *GitHub) Collect(ctx context.Context) ([]Item, error) {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("github API status %d", resp.StatusCode)
+	if err := checkStatus(resp); err != nil {
+		return nil, fmt.Errorf("github API: %w", err)
 	}
 
 	var result ghSearchResult
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return nil, fmt.Errorf("decode github response: %w", err)
 	}
+	return result.Items, nil
+}
 
-	var items []Item
-	for _, repo := range result.Items {
-		tags := repo.Topics
-		if repo.Language != "" {
-			tags = append(tags, repo.Language)
+var ghRepoNameRe = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+
+// GitHubRepoFromURL returns "owner/repo" for URLs pointing into a GitHub
+// repository (including its sub-pages), or "" for anything else.
+func GitHubRepoFromURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Host != "github.com" && u.Host != "www.github.com") {
+		return ""
+	}
+	parts := strings.SplitN(strings.Trim(u.Path, "/"), "/", 3)
+	if len(parts) < 2 {
+		return ""
+	}
+	name := parts[0] + "/" + strings.TrimSuffix(parts[1], ".git")
+	if !ghRepoNameRe.MatchString(name) {
+		return ""
+	}
+	return name
+}
+
+// repoll refreshes star counts for stored repositories that were discovered
+// within the tracking window and are not already part of this run.
+func (g *GitHub) repoll(ctx context.Context, skip map[string]bool) ([]Item, error) {
+	// The GraphQL API does not allow anonymous access.
+	if g.store == nil || g.token == "" {
+		return nil, nil
+	}
+
+	window := time.Now().AddDate(0, 0, -g.trackDays)
+	stored, err := g.store.ListItemsByInstance(ctx, g.instance, window, g.trackLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	discovered := make(map[string]time.Time)
+	var names []string
+	for _, item := range stored {
+		if skip[item.ExternalID] || !ghRepoNameRe.MatchString(item.ExternalID) {
+			continue
 		}
+		at := discoveredAt(item)
+		if at.Before(window) {
+			continue
+		}
+		discovered[item.ExternalID] = at
+		names = append(names, item.ExternalID)
+	}
 
-		items = append(items, Item{
-			ID:         fmt.Sprintf("github:%s", repo.FullName),
-			Source:     SourceGitHub,
-			ExternalID: repo.FullName,
-			Title:      repo.FullName,
-			URL:        repo.HTMLURL,
-			Description: repo.Description,
-			Author:     repo.Owner.Login,
-			Score:      repo.Stars,
-			Comments:   repo.Forks,
-			Tags:       tags,
-			PublishedAt: repo.CreatedAt,
-			CollectedAt: time.Now().UTC(),
-			Extra: map[string]any{
-				"language":    repo.Language,
-				"open_issues": repo.OpenIssues,
-				"watchers":    repo.Watchers,
-			},
-		})
+	var items []Item
+	for start := 0; start < len(names); start += ghGraphQLBatch {
+		end := min(start+ghGraphQLBatch, len(names))
+		repos, err := g.fetchRepos(ctx, names[start:end])
+		if err != nil {
+			return items, err
+		}
+		for _, repo := range repos {
+			at, ok := discovered[repo.FullName]
+			if !ok {
+				// Renamed repository; keep tracking under the new name.
+				at = time.Now().UTC()
+			}
+			items = append(items, repo.toItem(at))
+		}
 	}
 
 	return items, nil
 }
 
+// discoveredAt returns when a stored GitHub item was last seen by a
+// discovery pass.
+func discoveredAt(item Item) time.Time {
+	if s, ok := item.Extra["discovered_at"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			return t
+		}
+	}
+	return item.PublishedAt
+}
+
+const ghRepoFragment = `fragment repo on Repository {
+  nameWithOwner url description stargazerCount forkCount createdAt
+  owner { login }
+  primaryLanguage { name }
+  repositoryTopics(first: 10) { nodes { topic { name } } }
+  issues(states: OPEN) { totalCount }
+  watchers { totalCount }
+}`
+
+// fetchRepos loads a batch of repositories in a single GraphQL query using
+// one aliased field per repository.
+func (g *GitHub) fetchRepos(ctx context.Context, names []string) ([]ghRepo, error) {
+	var q strings.Builder
+	q.WriteString("query {\n")
+	for i, name := range names {
+		owner, repo, _ := strings.Cut(name, "/")
+		fmt.Fprintf(&q, "  r%d: repository(owner: %q, name: %q) { ...repo }\n", i, owner, repo)
+	}
+	q.WriteString("}\n")
+	q.WriteString(ghRepoFragment)
+
+	body, err := json.Marshal(map[string]string{"query": q.String()})
+	if err != nil {
+		return nil, fmt.Errorf("marshal github graphql: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.github.com/graphql", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create github graphql request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+g.token)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch github graphql: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return nil, fmt.Errorf("github graphql: %w", err)
+	}
+
+	// Deleted or private repositories come back as null with a per-field
+	// error, which is expected and ignored.
+	var result struct {
+		Data map[string]*ghGraphQLRepo `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode github graphql: %w", err)
+	}
+
+	var repos []ghRepo
+	for _, r := range result.Data {
+		if r != nil {
+			repos = append(repos, r.toRepo())
+		}
+	}
+	return repos, nil
+}
+
+func (repo ghRepo) toItem(discovered time.Time) Item {
+	tags := repo.Topics
+	if repo.Language != "" {
+		tags = append(tags, repo.Language)
+	}
+
+	return Item{
+		ID:          fmt.Sprintf("github:%s", repo.FullName),
+		Source:      SourceGitHub,
+		ExternalID:  repo.FullName,
+		Title:       repo.FullName,
+		URL:         repo.HTMLURL,
+		Description: repo.Description,
+		Author:      repo.Owner.Login,
+		Score:       repo.Stars,
+		Comments:    repo.Forks,
+		Tags:        tags,
+		PublishedAt: repo.CreatedAt,
+		CollectedAt: time.Now().UTC(),
+		Extra: map[string]any{
+			"language":      repo.Language,
+			"open_issues":   repo.OpenIssues,
+			"watchers":      repo.Watchers,
+			"discovered_at": discovered.UTC().Format(time.RFC3339),
+		},
+	}
+}
+
 type ghSearchResult struct {
 	TotalCount int      `json:"total_count"`
 	Items      []ghRepo `json:"items"`
@@ -115,3 +409,98 @@ type ghRepo struct {
 type ghOwner struct {
 	Login string `json:"login"`
 }
+
+type ghGraphQLRepo struct {
+	NameWithOwner   string    `json:"nameWithOwner"`
+	URL             string    `json:"url"`
+	Description     string    `json:"description"`
+	StargazerCount  int       `json:"stargazerCount"`
+	ForkCount       int       `json:"forkCount"`
+	CreatedAt       time.Time `json:"createdAt"`
+	Owner           ghOwner   `json:"owner"`
+	PrimaryLanguage *struct {
+		Name string `json:"name"`
+	} `json:"primaryLanguage"`
+	RepositoryTopics struct {
+		Nodes []struct {
+			Topic struct {
+				Name string `json:"name"`
+			} `json:"topic"`
+		} `json:"nodes"`
+	} `json:"repositoryTopics"`
+	Issues struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"issues"`
+	Watchers struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"watchers"`
+}
+
+func (r *ghGraphQLRepo) toRepo() ghRepo {
+	repo := ghRepo{
+		FullName:    r.NameWithOwner,
+		HTMLURL:     r.URL,
+		Description: r.Description,
+		Stars:       r.StargazerCount,
+		Forks:       r.ForkCount,
+		Watchers:    r.Watchers.TotalCount,
+		OpenIssues:  r.Issues.TotalCount,
+		CreatedAt:   r.CreatedAt,
+		Owner:       r.Owner,
+	}
+	if r.PrimaryLanguage != nil {
+		repo.Language = r.PrimaryLanguage.Name
+	}
+	for _, n := range r.RepositoryTopics.Nodes {
+		repo.Topics = append(repo.Topics, n.Topic.Name)
+	}
+	return repo
+}
+
+// GitHubConfig for GitHub trending collector.
+type GitHubConfig struct {
+	Enabled    bool     `yaml:"enabled"`
+	Token      string   `yaml:"token"`
+	Mode       string   `yaml:"mode"`        // "search", "trending" or "both"
+	Languages  []string `yaml:"languages"`   // trending page languages (empty = all)
+	Since      []string `yaml:"since"`       // trending periods: daily, weekly, monthly
+	TrackDays  int      `yaml:"track_days"`  // keep re-polling discovered repos for this long
+	TrackLimit int      `yaml:"track_limit"` // max stored repos re-polled per run
+
+	Releases    []string `yaml:"releases"`     // owner/repo watchlist for new releases and tags
+	ReleaseDays int      `yaml:"release_days"` // how far back to report releases
+}
+
+func init() {
+	Register(Factory[GitHubConfig]{
+		Type: SourceGitHub,
+		Defaults: func() GitHubConfig {
+			return GitHubConfig{
+				Enabled:     true,
+				Mode:        GitHubModeSearch,
+				Since:       []string{"daily"},
+				TrackDays:   14,
+				TrackLimit:  500,
+				ReleaseDays: 7,
+			}
+		},
+		Env: func(c *GitHubConfig) {
+			if v := os.Getenv("GITHUB_TOKEN"); v != "" {
+				c.Token = v
+			}
+		},
+		Enabled: func(c GitHubConfig) bool { return c.Enabled },
+		New: func(c GitHubConfig, deps Deps) ([]Source, error) {
+			return []Source{NewGitHub(GitHubOptions{
+				Token:       c.Token,
+				Mode:        c.Mode,
+				Languages:   c.Languages,
+				Since:       c.Since,
+				TrackDays:   c.TrackDays,
+				TrackLimit:  c.TrackLimit,
+				Releases:    c.Releases,
+				ReleaseDays: c.ReleaseDays,
+			}, deps.Filter, deps.Store)}, nil
+		},
+	})
+}
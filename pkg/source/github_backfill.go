@@ -0,0 +1,110 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	ghHistoryRepos = 5  // most starred repos per day that get a star history
+	ghHistoryPages = 10 // stargazer pages (100 stars each) read per repo
+)
+
+// Backfill returns the AI repositories created between from and to, most
+// starred first, searched one day at a time. With a token, the top repos of
+// each day get their star history from the stargazers API.
+func (g *GitHub) Backfill(ctx context.Context, from, to time.Time) ([]Item, error) {
+	var (
+		items   []Item
+		ok      int
+		lastErr error
+	)
+
+	for _, day := range days(from, to) {
+		query := fmt.Sprintf("created:%s..%s %s",
+			day[0].UTC().Format(time.RFC3339), day[1].Add(-time.Second).UTC().Format(time.RFC3339), ghTopicQuery)
+		repos, err := g.search(ctx, query, "stars")
+		if err != nil {
+			fmt.Printf("  github backfill %s error: %v\n", day[0].Format("2006-01-02"), err)
+			lastErr = err
+			continue
+		}
+		ok++
+
+		for i, repo := range repos {
+			item := repo.toItem(repo.CreatedAt)
+			if g.token != "" && i < ghHistoryRepos {
+				history, err := g.starHistory(ctx, repo.FullName, to)
+				if err != nil {
+					fmt.Printf("  github star history %s error: %v\n", repo.FullName, err)
+				}
+				item.History = history
+			}
+			items = append(items, item)
+		}
+	}
+
+	if ok == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return items, nil
+}
+
+// starHistory rebuilds a repository's star count per hour up to the given
+// time from the timestamps of its first stargazers. Fork history isn't
+// exposed, so Comments stays 0.
+func (g *GitHub) starHistory(ctx context.Context, name string, until time.Time) ([]Snapshot, error) {
+	var (
+		history []Snapshot
+		stars   int
+	)
+
+	for page := 1; page <= ghHistoryPages; page++ {
+		reqURL := fmt.Sprintf("https://api.github.com/repos/%s/stargazers?per_page=100&page=%d", name, page)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+		if err != nil {
+			return history, fmt.Errorf("create github request: %w", err)
+		}
+		// The star media type adds starred_at to each stargazer.
+		req.Header.Set("Accept", "application/vnd.github.star+json")
+		req.Header.Set("Authorization", "Bearer "+g.token)
+
+		resp, err := g.client.Do(req)
+		if err != nil {
+			return history, fmt.Errorf("fetch github stargazers: %w", err)
+		}
+
+		var batch []struct {
+			StarredAt time.Time `json:"starred_at"`
+		}
+		err = checkStatus(resp)
+		if err == nil {
+			err = json.NewDecoder(resp.Body).Decode(&batch)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return history, fmt.Errorf("github stargazers: %w", err)
+		}
+
+		// Stargazers are listed oldest first.
+		for _, s := range batch {
+			if s.StarredAt.After(until) {
+				return history, nil
+			}
+			stars++
+			at := s.StarredAt.UTC().Truncate(time.Hour).Add(time.Hour)
+			if n := len(history); n > 0 && history[n-1].At.Equal(at) {
+				history[n-1].Score = stars
+			} else {
+				history = append(history, Snapshot{At: at, Score: stars})
+			}
+		}
+		if len(batch) < 100 {
+			break
+		}
+	}
+	return history, nil
+}
@@ -0,0 +1,220 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// releases collects recent releases of the watched repositories. Repos that
+// only push tags (no GitHub releases) fall back to their tags feed.
+func (g *GitHub) releases(ctx context.Context) ([]Item, error) {
+	cutoff := time.Now().AddDate(0, 0, -g.releaseDays)
+
+	var (
+		items   []Item
+		lastErr error
+		ok      int
+	)
+
+	for _, name := range g.watch {
+		if !ghRepoNameRe.MatchString(name) {
+			fmt.Printf("  github releases: invalid repository %q (want owner/repo)\n", name)
+			continue
+		}
+
+		repoItems, err := g.repoReleases(ctx, name, cutoff)
+		if err != nil {
+			fmt.Printf("  github releases %s error: %v\n", name, err)
+			lastErr = err
+			continue
+		}
+		ok++
+		items = append(items, repoItems...)
+	}
+
+	if ok == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return items, nil
+}
+
+func (g *GitHub) repoReleases(ctx context.Context, name string, cutoff time.Time) ([]Item, error) {
+	var releases []ghRelease
+	if err := g.getJSON(ctx, fmt.Sprintf("https://api.github.com/repos/%s/releases?per_page=20", name), &releases); err != nil {
+		return nil, err
+	}
+	if len(releases) == 0 {
+		var err error
+		if releases, err = g.tagsFeed(ctx, name); err != nil {
+			return nil, err
+		}
+	}
+
+	var recent []ghRelease
+	for _, r := range releases {
+		if !r.Draft && r.PublishedAt.After(cutoff) {
+			recent = append(recent, r)
+		}
+	}
+	if len(recent) == 0 {
+		return nil, nil
+	}
+
+	// Star count for context; a failure here shouldn't drop the releases.
+	var repo ghRepo
+	if err := g.getJSON(ctx, "https://api.github.com/repos/"+name, &repo); err != nil {
+		fmt.Printf("  github releases %s: repo lookup error: %v\n", name, err)
+		repo.FullName = name
+	}
+
+	items := make([]Item, 0, len(recent))
+	for _, r := range recent {
+		items = append(items, r.toItem(name, repo))
+	}
+	return items, nil
+}
+
+// tagsFeed reads a repository's tags from its Atom feed, which carries
+// dates (unlike the tags API) and needs no API quota.
+func (g *GitHub) tagsFeed(ctx context.Context, name string) ([]ghRelease, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://github.com/%s/tags.atom", name), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create github tags request: %w", err)
+	}
+	req.Header.Set("User-Agent", "airadar/1.0")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch github tags: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return nil, fmt.Errorf("github tags: %w", err)
+	}
+
+	feed, err := gofeed.NewParser().Parse(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parse github tags: %w", err)
+	}
+
+	var tags []ghRelease
+	for _, entry := range feed.Items {
+		t := ghRelease{
+			TagName: strings.TrimSpace(entry.Title),
+			HTMLURL: entry.Link,
+			Body:    stripHTML(entry.Content),
+			tagOnly: true,
+		}
+		if entry.UpdatedParsed != nil {
+			t.PublishedAt = entry.UpdatedParsed.UTC()
+		} else if entry.PublishedParsed != nil {
+			t.PublishedAt = entry.PublishedParsed.UTC()
+		}
+		if entry.Author != nil {
+			t.Author.Login = entry.Author.Name
+		}
+		if t.TagName != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags, nil
+}
+
+func (g *GitHub) getJSON(ctx context.Context, reqURL string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("create github request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if g.token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.token)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch github: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return fmt.Errorf("github API: %w", err)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode github response: %w", err)
+	}
+	return nil
+}
+
+type ghRelease struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Body        string    `json:"body"`
+	HTMLURL     string    `json:"html_url"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+	Author      ghOwner   `json:"author"`
+	Reactions   struct {
+		TotalCount int `json:"total_count"`
+	} `json:"reactions"`
+
+	tagOnly bool // read from the tags feed, not a GitHub release
+}
+
+// toItem converts a release into an item titled "<repo> <tag>", plus the
+// release name when it adds something, so it reads like (and clusters with)
+// posts announcing the version.
+func (r ghRelease) toItem(fullName string, repo ghRepo) Item {
+	_, repoName, _ := strings.Cut(fullName, "/")
+
+	title := repoName + " " + r.TagName
+	if name := strings.TrimSpace(r.Name); name != "" && !strings.Contains(name, r.TagName) {
+		title += ": " + name
+	}
+
+	kind := "release"
+	if r.tagOnly {
+		kind = "tag"
+	}
+	tags := []string{kind}
+	if r.Prerelease {
+		tags = append(tags, "prerelease")
+	}
+
+	itemURL := r.HTMLURL
+	if itemURL == "" {
+		itemURL = fmt.Sprintf("https://github.com/%s/releases/tag/%s", fullName, r.TagName)
+	}
+
+	return Item{
+		ID:          fmt.Sprintf("github:%s@%s", fullName, r.TagName),
+		Source:      SourceGitHub,
+		ExternalID:  fullName + "@" + r.TagName,
+		Title:       title,
+		URL:         itemURL,
+		Description: truncate(strings.TrimSpace(r.Body), 500),
+		Author:      r.Author.Login,
+		Score:       r.Reactions.TotalCount,
+		Tags:        tags,
+		PublishedAt: r.PublishedAt.UTC(),
+		CollectedAt: time.Now().UTC(),
+		Extra: map[string]any{
+			"kind":        kind,
+			"repo":        fullName,
+			"version":     r.TagName,
+			"prerelease":  r.Prerelease,
+			"repo_stars":  repo.Stars,
+			"repo_forks":  repo.Forks,
+			"language":    repo.Language,
+			"description": repo.Description,
+		},
+	}
+}
@@ -0,0 +1,295 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	ghTrendingURL = "https://github.com/trending"
+
+	// readmeRecheck is how long a README filter result is reused; a
+	// repository's README rarely changes while it is trending.
+	readmeRecheck = 7 * 24 * time.Hour
+)
+
+// ghTrendingRepo is one row of the github.com/trending page.
+type ghTrendingRepo struct {
+	FullName    string
+	Description string
+	Language    string
+	Stars       int
+	Forks       int
+	PeriodStars int    // "1,234 stars today" / "this week"
+	Since       string // "daily", "weekly"
+}
+
+// trending scrapes the trending pages for every configured language and
+// period and returns the AI-related repositories.
+func (g *GitHub) trending(ctx context.Context) ([]ghTrendingRepo, error) {
+	languages := g.languages
+	if len(languages) == 0 {
+		languages = []string{""} // all languages
+	}
+
+	var (
+		repos   []ghTrendingRepo
+		seen    = make(map[string]int)
+		lastErr error
+		pages   int
+	)
+
+	for _, since := range g.since {
+		for _, lang := range languages {
+			page, err := g.fetchTrending(ctx, lang, since)
+			if err != nil {
+				fmt.Printf("  github trending %s/%s error: %v\n", langOrAll(lang), since, err)
+				lastErr = err
+				continue
+			}
+			pages++
+
+			for _, repo := range page {
+				if i, ok := seen[repo.FullName]; ok {
+					// Listed under several periods: keep the daily count.
+					if repos[i].Since != "daily" && repo.Since == "daily" {
+						repos[i] = repo
+					}
+					continue
+				}
+				if !g.matchesAI(ctx, repo) {
+					continue
+				}
+				seen[repo.FullName] = len(repos)
+				repos = append(repos, repo)
+			}
+		}
+	}
+
+	if pages == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return repos, nil
+}
+
+func langOrAll(lang string) string {
+	if lang == "" {
+		return "all"
+	}
+	return lang
+}
+
+// fetchTrending parses one github.com/trending page.
+func (g *GitHub) fetchTrending(ctx context.Context, lang, since string) ([]ghTrendingRepo, error) {
+	reqURL := ghTrendingURL
+	if lang != "" {
+		reqURL += "/" + url.PathEscape(strings.ToLower(lang))
+	}
+	reqURL += "?since=" + url.QueryEscape(since)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create github trending request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("User-Agent", "airadar/1.0")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch github trending: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return nil, fmt.Errorf("github trending: %w", err)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parse github trending: %w", err)
+	}
+
+	var repos []ghTrendingRepo
+	doc.Find("article.Box-row").Each(func(_ int, row *goquery.Selection) {
+		href, _ := row.Find("h2 a").First().Attr("href")
+		name := strings.Trim(strings.TrimSpace(href), "/")
+		if !ghRepoNameRe.MatchString(name) {
+			return
+		}
+
+		repos = append(repos, ghTrendingRepo{
+			FullName:    name,
+			Description: strings.Join(strings.Fields(row.Find("p").First().Text()), " "),
+			Language:    strings.TrimSpace(row.Find(`[itemprop="programmingLanguage"]`).First().Text()),
+			Stars:       parseCount(row.Find(`a[href$="/stargazers"]`).First().Text()),
+			Forks:       parseCount(row.Find(`a[href$="/forks"]`).First().Text()),
+			PeriodStars: parseCount(row.Find("span.float-sm-right").First().Text()),
+			Since:       since,
+		})
+	})
+
+	if len(repos) == 0 {
+		return nil, fmt.Errorf("github trending: no repositories found (page layout changed?)")
+	}
+	return repos, nil
+}
+
+var countRe = regexp.MustCompile(`[\d,]+`)
+
+// parseCount extracts the first number from text like "1,234 stars today".
+func parseCount(s string) int {
+	n, _ := strconv.Atoi(strings.ReplaceAll(countRe.FindString(s), ",", ""))
+	return n
+}
+
+// matchesAI applies the AI filter to the repository name and description,
+// falling back to the start of the README. README results are cached since
+// the same repositories stay on the trending page for days.
+func (g *GitHub) matchesAI(ctx context.Context, repo ghTrendingRepo) bool {
+	if g.filter == nil {
+		return true
+	}
+	if g.filter.MatchesAI(strings.ReplaceAll(repo.FullName, "-", " ") + " " + repo.Description) {
+		return true
+	}
+
+	if match, ok := g.readmes.get(repo.FullName); ok {
+		return match
+	}
+	readme, err := g.readme(ctx, repo.FullName)
+	if err != nil {
+		// Don't cache failures (rate limits, timeouts); retry next run.
+		return false
+	}
+	match := g.filter.MatchesAI(readme)
+	g.readmes.put(repo.FullName, match)
+	return match
+}
+
+// readmeCache remembers whether trending repositories' READMEs matched the
+// filter. Entries expire after readmeRecheck and are dropped then, so it
+// only holds the repositories seen recently.
+type readmeCache struct {
+	mu      sync.Mutex
+	entries map[string]readmeEntry
+}
+
+type readmeEntry struct {
+	match   bool
+	checked time.Time
+}
+
+func newReadmeCache() *readmeCache {
+	return &readmeCache{entries: make(map[string]readmeEntry)}
+}
+
+func (c *readmeCache) get(repo string) (match, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[repo]
+	if !ok || time.Since(e.checked) > readmeRecheck {
+		return false, false
+	}
+	return e.match, true
+}
+
+func (c *readmeCache) put(repo string, match bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for name, e := range c.entries {
+		if now.Sub(e.checked) > readmeRecheck {
+			delete(c.entries, name)
+		}
+	}
+	c.entries[repo] = readmeEntry{match: match, checked: now}
+}
+
+// readme returns a snippet from the start of a repository's README.
+func (g *GitHub) readme(ctx context.Context, fullName string) (string, error) {
+	reqURL := fmt.Sprintf("https://api.github.com/repos/%s/readme", fullName)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("create github readme request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github.raw")
+	if g.token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.token)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetch github readme: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil // no README
+	}
+	if err := checkStatus(resp); err != nil {
+		return "", fmt.Errorf("github readme: %w", err)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 8*1024))
+	if err != nil {
+		return "", fmt.Errorf("read github readme: %w", err)
+	}
+	return stripHTML(string(data)), nil
+}
+
+func (repo ghTrendingRepo) toItem(now time.Time) Item {
+	var tags []string
+	if repo.Language != "" {
+		tags = append(tags, repo.Language)
+	}
+
+	owner, _, _ := strings.Cut(repo.FullName, "/")
+	item := Item{
+		ID:          fmt.Sprintf("github:%s", repo.FullName),
+		Source:      SourceGitHub,
+		ExternalID:  repo.FullName,
+		Title:       repo.FullName,
+		URL:         "https://github.com/" + repo.FullName,
+		Description: repo.Description,
+		Author:      owner,
+		Score:       repo.Stars,
+		Comments:    repo.Forks,
+		Tags:        tags,
+		// The trending page doesn't show creation dates; first sighting
+		// is the closest we have.
+		PublishedAt: now,
+		CollectedAt: now,
+		Extra: map[string]any{
+			"language":      repo.Language,
+			"discovered_at": now.Format(time.RFC3339),
+		},
+	}
+	repo.addTo(&item)
+	return item
+}
+
+// addTo records the trending-page star count on an item.
+func (repo ghTrendingRepo) addTo(item *Item) {
+	if item.Extra == nil {
+		item.Extra = make(map[string]any)
+	}
+	item.Extra["trending"] = repo.Since
+	switch repo.Since {
+	case "daily":
+		item.Extra["stars_today"] = repo.PeriodStars
+	case "weekly":
+		item.Extra["stars_this_week"] = repo.PeriodStars
+	case "monthly":
+		item.Extra["stars_this_month"] = repo.PeriodStars
+	}
+}
@@ -0,0 +1,39 @@
+package source
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReadmeCache(t *testing.T) {
+	c := newReadmeCache()
+	c.put("old/repo", true)
+	c.entries["old/repo"] = readmeEntry{match: true, checked: time.Now().Add(-readmeRecheck - time.Hour)}
+
+	if _, ok := c.get("old/repo"); ok {
+		t.Fatal("expired README result was reused")
+	}
+
+	// Collects of the scheduler and the API may check READMEs at once.
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			name := fmt.Sprintf("repo/%d", i)
+			c.put(name, i%2 == 0)
+			c.get(name)
+		})
+	}
+	wg.Wait()
+
+	if match, ok := c.get("repo/2"); !ok || !match {
+		t.Fatalf("get(repo/2) = %v, %v, want a cached match", match, ok)
+	}
+	if _, ok := c.entries["old/repo"]; ok {
+		t.Fatal("expired entry was kept")
+	}
+	if len(c.entries) != 8 {
+		t.Fatalf("cache holds %d entries, want 8", len(c.entries))
+	}
+}
@@ -5,124 +5,252 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
 
-const hnBaseURL = "https://hacker-news.firebaseio.com/v0"
+const (
+	hnBaseURL      = "https://hacker-news.firebaseio.com/v0"
+	hnAlgoliaURL   = "https://hn.algolia.com/api/v1"
+	hnAlgoliaBatch = 100 // story IDs per Algolia lookup
+)
+
+// HNSearch is a keyword search against the HN Algolia API.
+type HNSearch struct {
+	Query  string
+	Tags   string        // Algolia tag filter, e.g. "story", "show_hn", "ask_hn" (default: "story")
+	Window time.Duration // only stories newer than this (default: 24h)
+}
 
 // HackerNews collects AI-related stories from Hacker News.
 type HackerNews struct {
-	client *http.Client
-	limit  int
-	filter *Filter
+	client   *http.Client
+	limit    int
+	lists    []string
+	searches []HNSearch
+	filter   *Filter
 }
 
 // NewHackerNews creates a new HN collector.
-func NewHackerNews(limit int, filter *Filter) *HackerNews {
+// lists are Firebase story lists: "topstories", "newstories", "beststories", "showstories".
+func NewHackerNews(limit int, lists []string, searches []HNSearch, filter *Filter) *HackerNews {
 	if limit <= 0 {
 		limit = 100
 	}
+	if len(lists) == 0 && len(searches) == 0 {
+		lists = []string{"topstories"}
+	}
 	return &HackerNews{
-		client: &http.Client{Timeout: 30 * time.Second},
-		limit:  limit,
-		filter: filter,
+		client:   newHTTPClient(30 * time.Second),
+		limit:    limit,
+		lists:    lists,
+		searches: searches,
+		filter:   filter,
 	}
 }
 
 func (h *HackerNews) Name() SourceType { return SourceHackerNews }
 
 func (h *HackerNews) Collect(ctx context.Context) ([]Item, error) {
-	ids, err := h.fetchTopStories(ctx)
-	if err != nil {
-		return nil, err
-	}
+	var (
+		ids     []int
+		listOf  = make(map[int]string)
+		lastErr error
+	)
 
-	if len(ids) > h.limit {
-		ids = ids[:h.limit]
+	for _, list := range h.lists {
+		listIDs, err := h.fetchStoryList(ctx, list)
+		if err != nil {
+			fmt.Printf("  hn %s error: %v\n", list, err)
+			lastErr = err
+			continue
+		}
+		if len(listIDs) > h.limit {
+			listIDs = listIDs[:h.limit]
+		}
+		for _, id := range listIDs {
+			if _, ok := listOf[id]; !ok {
+				listOf[id] = list
+				ids = append(ids, id)
+			}
+		}
 	}
 
-	var (
-		mu    sync.Mutex
-		items []Item
-		wg    sync.WaitGroup
-		sem   = make(chan struct{}, 10) // concurrency limit
-	)
+	var items []Item
+	seen := make(map[string]bool)
 
-	for _, id := range ids {
-		wg.Add(1)
-		go func(id int) {
-			defer wg.Done()
-			sem <- struct{}{}
-			defer func() { <-sem }()
+	for _, story := range h.fetchStories(ctx, ids) {
+		// Filter for AI-related content.
+		text := story.Title + " " + story.URL
+		if h.filter != nil && !h.filter.MatchesAI(text) {
+			continue
+		}
 
-			story, err := h.fetchItem(ctx, id)
-			if err != nil || story == nil {
-				return
-			}
+		item := story.toItem()
+		item.Extra = map[string]any{"list": listOf[story.ID]}
+		seen[item.ID] = true
+		items = append(items, item)
+	}
 
-			// Filter for AI-related content.
-			text := story.Title + " " + story.URL
-			if h.filter != nil && !h.filter.MatchesAI(text) {
-				return
-			}
+	// Keyword searches are explicit, so their results skip the AI filter.
+	searchOK := false
+	for _, s := range h.searches {
+		stories, err := h.search(ctx, s)
+		if err != nil {
+			fmt.Printf("  hn search %q error: %v\n", s.Query, err)
+			lastErr = err
+			continue
+		}
+		searchOK = true
 
-			item := Item{
-				ID:          fmt.Sprintf("hackernews:%d", story.ID),
-				Source:      SourceHackerNews,
-				ExternalID:  fmt.Sprintf("%d", story.ID),
-				Title:       story.Title,
-				URL:         story.URL,
-				Author:      story.By,
-				Score:       story.Score,
-				Comments:    story.Descendants,
-				PublishedAt: time.Unix(story.Time, 0).UTC(),
-				CollectedAt: time.Now().UTC(),
+		for _, story := range stories {
+			item := story.toItem()
+			if seen[item.ID] {
+				continue
 			}
-			if item.URL == "" {
-				item.URL = fmt.Sprintf("https://news.ycombinator.com/item?id=%d", story.ID)
-			}
-
-			mu.Lock()
+			seen[item.ID] = true
+			item.Extra = map[string]any{"query": s.Query}
 			items = append(items, item)
-			mu.Unlock()
-		}(id)
+		}
 	}
 
-	wg.Wait()
+	if len(ids) == 0 && !searchOK && lastErr != nil {
+		return nil, lastErr
+	}
 	return items, nil
 }
 
 type hnStory struct {
-	ID          int    `json:"id"`
-	Title       string `json:"title"`
-	URL         string `json:"url"`
-	Score       int    `json:"score"`
-	By          string `json:"by"`
-	Time        int64  `json:"time"`
-	Descendants int    `json:"descendants"`
-	Type        string `json:"type"`
-}
-
-func (h *HackerNews) fetchTopStories(ctx context.Context) ([]int, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, hnBaseURL+"/topstories.json", nil)
+	ID          int      `json:"id"`
+	Title       string   `json:"title"`
+	URL         string   `json:"url"`
+	Score       int      `json:"score"`
+	By          string   `json:"by"`
+	Time        int64    `json:"time"`
+	Descendants int      `json:"descendants"`
+	Type        string   `json:"type"`
+	Text        string   `json:"text"`
+	Tags        []string `json:"-"`
+}
+
+func (s *hnStory) toItem() Item {
+	item := Item{
+		ID:          fmt.Sprintf("hackernews:%d", s.ID),
+		Source:      SourceHackerNews,
+		ExternalID:  fmt.Sprintf("%d", s.ID),
+		Title:       s.Title,
+		URL:         s.URL,
+		Description: truncate(stripHTML(s.Text), 500),
+		Author:      s.By,
+		Score:       s.Score,
+		Comments:    s.Descendants,
+		Tags:        s.Tags,
+		PublishedAt: time.Unix(s.Time, 0).UTC(),
+		CollectedAt: time.Now().UTC(),
+	}
+	if item.URL == "" {
+		item.URL = fmt.Sprintf("https://news.ycombinator.com/item?id=%d", s.ID)
+	}
+	return item
+}
+
+func (h *HackerNews) fetchStoryList(ctx context.Context, list string) ([]int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, hnBaseURL+"/"+list+".json", nil)
 	if err != nil {
 		return nil, fmt.Errorf("create hn request: %w", err)
 	}
 
 	resp, err := h.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("fetch hn top stories: %w", err)
+		return nil, fmt.Errorf("fetch hn %s: %w", list, err)
 	}
 	defer resp.Body.Close()
 
+	if err := checkStatus(resp); err != nil {
+		return nil, fmt.Errorf("hn %s: %w", list, err)
+	}
+
 	var ids []int
 	if err := json.NewDecoder(resp.Body).Decode(&ids); err != nil {
-		return nil, fmt.Errorf("decode hn top stories: %w", err)
+		return nil, fmt.Errorf("decode hn %s: %w", list, err)
 	}
 	return ids, nil
 }
 
+// fetchStories resolves story IDs to stories. Points and comment counts are
+// looked up in bulk through Algolia; IDs Algolia hasn't indexed yet (very new
+// stories) fall back to one Firebase request each.
+func (h *HackerNews) fetchStories(ctx context.Context, ids []int) []hnStory {
+	var stories []hnStory
+	found := make(map[int]bool)
+
+	for start := 0; start < len(ids); start += hnAlgoliaBatch {
+		end := min(start+hnAlgoliaBatch, len(ids))
+
+		batch, err := h.lookupAlgolia(ctx, ids[start:end])
+		if err != nil {
+			fmt.Printf("  hn algolia lookup error (falling back to per-item): %v\n", err)
+			continue
+		}
+		for _, s := range batch {
+			found[s.ID] = true
+			stories = append(stories, s)
+		}
+	}
+
+	var missing []int
+	for _, id := range ids {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	return append(stories, h.fetchItems(ctx, missing)...)
+}
+
+// fetchItems fetches stories one by one from Firebase.
+func (h *HackerNews) fetchItems(ctx context.Context, ids []int) []hnStory {
+	var (
+		mu      sync.Mutex
+		stories []hnStory
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, 10) // concurrency limit
+		failed  int
+		lastErr error
+	)
+
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			story, err := h.fetchItem(ctx, id)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed++
+				lastErr = err
+				return
+			}
+			if story != nil {
+				stories = append(stories, *story)
+			}
+		}(id)
+	}
+
+	wg.Wait()
+	if failed > 0 {
+		fmt.Printf("  hn: %d of %d items failed, last error: %v\n", failed, len(ids), lastErr)
+	}
+	return stories
+}
+
 func (h *HackerNews) fetchItem(ctx context.Context, id int) (*hnStory, error) {
 	url := fmt.Sprintf("%s/item/%d.json", hnBaseURL, id)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -136,6 +264,10 @@ func (h *HackerNews) fetchItem(ctx context.Context, id int) (*hnStory, error) {
 	}
 	defer resp.Body.Close()
 
+	if err := checkStatus(resp); err != nil {
+		return nil, fmt.Errorf("hn item %d: %w", id, err)
+	}
+
 	var story hnStory
 	if err := json.NewDecoder(resp.Body).Decode(&story); err != nil {
 		return nil, fmt.Errorf("decode hn item %d: %w", id, err)
@@ -146,3 +278,238 @@ func (h *HackerNews) fetchItem(ctx context.Context, id int) (*hnStory, error) {
 	}
 	return &story, nil
 }
+
+// lookupAlgolia fetches a batch of stories by ID in a single request.
+func (h *HackerNews) lookupAlgolia(ctx context.Context, ids []int) ([]hnStory, error) {
+	tags := make([]string, len(ids))
+	for i, id := range ids {
+		tags[i] = "story_" + strconv.Itoa(id)
+	}
+
+	params := url.Values{}
+	params.Set("tags", "story,("+strings.Join(tags, ",")+")")
+	params.Set("hitsPerPage", strconv.Itoa(len(ids)))
+
+	return h.algolia(ctx, "search", params)
+}
+
+// search runs a keyword search over recent stories, newest first.
+func (h *HackerNews) search(ctx context.Context, s HNSearch) ([]hnStory, error) {
+	tags := s.Tags
+	if tags == "" {
+		tags = "story"
+	}
+	window := s.Window
+	if window <= 0 {
+		window = 24 * time.Hour
+	}
+
+	params := url.Values{}
+	params.Set("query", s.Query)
+	params.Set("tags", tags)
+	params.Set("numericFilters", fmt.Sprintf("created_at_i>%d", time.Now().Add(-window).Unix()))
+	params.Set("hitsPerPage", strconv.Itoa(h.limit))
+
+	return h.algolia(ctx, "search_by_date", params)
+}
+
+// Backfill returns the stories published between from and to. Lists are
+// replaced by the stories that made the front page, AI-filtered; keyword
+// searches run over the period as configured. Algolia only has current
+// points, so stories carry no history.
+func (h *HackerNews) Backfill(ctx context.Context, from, to time.Time) ([]Item, error) {
+	var (
+		items   []Item
+		seen    = make(map[string]bool)
+		ok      int
+		lastErr error
+	)
+
+	add := func(stories []hnStory, filtered bool, extra map[string]any) {
+		for _, story := range stories {
+			if filtered && h.filter != nil && !h.filter.MatchesAI(story.Title+" "+story.URL) {
+				continue
+			}
+			item := story.toItem()
+			if seen[item.ID] {
+				continue
+			}
+			seen[item.ID] = true
+			item.Extra = extra
+			items = append(items, item)
+		}
+	}
+
+	for _, day := range days(from, to) {
+		if len(h.lists) > 0 {
+			stories, err := h.searchRange(ctx, "", "front_page", day[0], day[1])
+			if err != nil {
+				fmt.Printf("  hn front page %s error: %v\n", day[0].Format("2006-01-02"), err)
+				lastErr = err
+			} else {
+				ok++
+			}
+			add(stories, true, map[string]any{"list": "front_page"})
+		}
+
+		for _, s := range h.searches {
+			tags := s.Tags
+			if tags == "" {
+				tags = "story"
+			}
+			stories, err := h.searchRange(ctx, s.Query, tags, day[0], day[1])
+			if err != nil {
+				fmt.Printf("  hn search %q %s error: %v\n", s.Query, day[0].Format("2006-01-02"), err)
+				lastErr = err
+			} else {
+				ok++
+			}
+			add(stories, false, map[string]any{"query": s.Query})
+		}
+	}
+
+	if ok == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return items, nil
+}
+
+// searchRange pages through all stories matching query and tags that were
+// created between from and to.
+func (h *HackerNews) searchRange(ctx context.Context, query, tags string, from, to time.Time) ([]hnStory, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("tags", tags)
+	params.Set("numericFilters", fmt.Sprintf("created_at_i>=%d,created_at_i<%d", from.Unix(), to.Unix()))
+	params.Set("hitsPerPage", strconv.Itoa(hnAlgoliaBatch))
+
+	var stories []hnStory
+	for page := 0; ; page++ {
+		params.Set("page", strconv.Itoa(page))
+		batch, pages, err := h.algoliaPage(ctx, "search_by_date", params)
+		if err != nil {
+			return stories, err
+		}
+		stories = append(stories, batch...)
+		if page+1 >= pages {
+			return stories, nil
+		}
+	}
+}
+
+func (h *HackerNews) algolia(ctx context.Context, endpoint string, params url.Values) ([]hnStory, error) {
+	stories, _, err := h.algoliaPage(ctx, endpoint, params)
+	return stories, err
+}
+
+// algoliaPage runs one Algolia query and also returns the number of result
+// pages.
+func (h *HackerNews) algoliaPage(ctx context.Context, endpoint string, params url.Values) ([]hnStory, int, error) {
+	reqURL := fmt.Sprintf("%s/%s?%s", hnAlgoliaURL, endpoint, params.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("create hn algolia request: %w", err)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("fetch hn algolia: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return nil, 0, fmt.Errorf("hn algolia: %w", err)
+	}
+
+	var result hnAlgoliaResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, 0, fmt.Errorf("decode hn algolia: %w", err)
+	}
+
+	stories := make([]hnStory, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		id, err := strconv.Atoi(hit.ObjectID)
+		if err != nil || hit.Title == "" {
+			continue
+		}
+
+		// Keep only the post-type tags ("show_hn", "ask_hn", ...).
+		var tags []string
+		for _, t := range hit.Tags {
+			if t == "show_hn" || t == "ask_hn" || t == "launch_hn" || t == "poll" {
+				tags = append(tags, t)
+			}
+		}
+
+		stories = append(stories, hnStory{
+			ID:          id,
+			Title:       hit.Title,
+			URL:         hit.URL,
+			Score:       hit.Points,
+			By:          hit.Author,
+			Time:        hit.CreatedAtI,
+			Descendants: hit.NumComments,
+			Type:        "story",
+			Text:        hit.StoryText,
+			Tags:        tags,
+		})
+	}
+	return stories, result.NbPages, nil
+}
+
+type hnAlgoliaResult struct {
+	Hits []struct {
+		ObjectID    string   `json:"objectID"`
+		Title       string   `json:"title"`
+		URL         string   `json:"url"`
+		Author      string   `json:"author"`
+		Points      int      `json:"points"`
+		NumComments int      `json:"num_comments"`
+		CreatedAtI  int64    `json:"created_at_i"`
+		StoryText   string   `json:"story_text"`
+		Tags        []string `json:"_tags"`
+	} `json:"hits"`
+	NbPages int `json:"nbPages"`
+}
+
+// HackerNewsConfig for Hacker News collector.
+type HackerNewsConfig struct {
+	Enabled  bool             `yaml:"enabled"`
+	Limit    int              `yaml:"limit"` // per list / search
+	Lists    []string         `yaml:"lists"` // topstories, newstories, beststories, showstories
+	Searches []HNSearchConfig `yaml:"searches"`
+}
+
+// HNSearchConfig is a keyword search through the HN Algolia API.
+type HNSearchConfig struct {
+	Query  string `yaml:"query"`
+	Tags   string `yaml:"tags"`   // story, show_hn, ask_hn (default: story)
+	Window string `yaml:"window"` // e.g. "24h" (default)
+}
+
+// ParseWindow returns the search window as time.Duration.
+func (s HNSearchConfig) ParseWindow() time.Duration {
+	d, err := time.ParseDuration(s.Window)
+	if err != nil {
+		return 24 * time.Hour
+	}
+	return d
+}
+
+func init() {
+	Register(Factory[HackerNewsConfig]{
+		Type:    SourceHackerNews,
+		Aliases: []string{"hn"},
+		Defaults: func() HackerNewsConfig {
+			return HackerNewsConfig{Enabled: true, Limit: 100, Lists: []string{"topstories"}}
+		},
+		Enabled: func(c HackerNewsConfig) bool { return c.Enabled },
+		New: func(c HackerNewsConfig, deps Deps) ([]Source, error) {
+			searches := make([]HNSearch, len(c.Searches))
+			for i, s := range c.Searches {
+				searches[i] = HNSearch{Query: s.Query, Tags: s.Tags, Window: s.ParseWindow()}
+			}
+			return []Source{NewHackerNews(c.Limit, c.Lists, searches, deps.Filter)}, nil
+		},
+	})
+}
@@ -0,0 +1,225 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// HFPapers collects the Hugging Face Daily Papers: arXiv papers picked and
+// upvoted by the community, which gives research a popularity signal that
+// arXiv itself lacks.
+type HFPapers struct {
+	client *http.Client
+	token  string
+	days   int
+	limit  int
+}
+
+// NewHFPapers creates a new Hugging Face Daily Papers collector.
+// days is how many daily lists to poll, counting back from today; papers
+// keep collecting upvotes for a few days after they are featured.
+func NewHFPapers(token string, days, limit int) *HFPapers {
+	if days <= 0 {
+		days = 3
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	return &HFPapers{
+		client: newHTTPClient(30 * time.Second),
+		token:  token,
+		days:   days,
+		limit:  limit,
+	}
+}
+
+func (h *HFPapers) Name() SourceType { return SourceHFPapers }
+
+func (h *HFPapers) Collect(ctx context.Context) ([]Item, error) {
+	var (
+		items   []Item
+		seen    = make(map[string]bool)
+		lastErr error
+		ok      int
+	)
+
+	today := time.Now().UTC()
+	for d := 0; d < h.days; d++ {
+		date := today.AddDate(0, 0, -d).Format("2006-01-02")
+		papers, err := h.fetchDay(ctx, date)
+		if err != nil {
+			fmt.Printf("  hfpapers %s error: %v\n", date, err)
+			lastErr = err
+			continue
+		}
+		ok++
+
+		for _, p := range papers {
+			if p.Paper.ID == "" || seen[p.Paper.ID] {
+				continue
+			}
+			seen[p.Paper.ID] = true
+			items = append(items, p.toItem())
+		}
+	}
+
+	if ok == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return items, nil
+}
+
+func (h *HFPapers) fetchDay(ctx context.Context, date string) ([]hfDailyPaper, error) {
+	params := url.Values{}
+	params.Set("date", date)
+	params.Set("limit", strconv.Itoa(h.limit))
+
+	reqURL := fmt.Sprintf("%s/api/daily_papers?%s", hfBaseURL, params.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create hfpapers request: %w", err)
+	}
+	req.Header.Set("User-Agent", "airadar/1.0")
+	if h.token != "" {
+		req.Header.Set("Authorization", "Bearer "+h.token)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch hfpapers: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return nil, fmt.Errorf("hfpapers: %w", err)
+	}
+
+	var papers []hfDailyPaper
+	if err := json.NewDecoder(resp.Body).Decode(&papers); err != nil {
+		return nil, fmt.Errorf("decode hfpapers: %w", err)
+	}
+	return papers, nil
+}
+
+func (p hfDailyPaper) toItem() Item {
+	paper := p.Paper
+
+	var authors []string
+	for _, a := range paper.Authors {
+		authors = append(authors, a.Name)
+	}
+	author := ""
+	if len(authors) > 0 {
+		author = authors[0]
+		if len(authors) > 1 {
+			author += " et al."
+		}
+	}
+
+	title := paper.Title
+	if title == "" {
+		title = p.Title
+	}
+	summary := paper.AISummary
+	if summary == "" {
+		summary = paper.Summary
+	}
+
+	published := paper.PublishedAt
+	if published.IsZero() {
+		published = p.PublishedAt
+	}
+	if published.IsZero() {
+		published = time.Now().UTC()
+	}
+
+	extra := map[string]any{
+		"arxiv_id":     paper.ID,
+		"arxiv_url":    "https://arxiv.org/abs/" + paper.ID,
+		"authors":      authors,
+		"submitted_by": p.SubmittedBy.User,
+	}
+	if !p.PublishedAt.IsZero() {
+		extra["featured_at"] = p.PublishedAt.UTC().Format(time.RFC3339)
+	}
+	if p.Organization != nil {
+		extra["organization"] = p.Organization.Name
+	}
+	if repo := GitHubRepoFromURL(paper.GithubRepo); repo != "" {
+		extra["code_repo"] = repo
+		extra["code_url"] = paper.GithubRepo
+		extra["github_stars"] = paper.GithubStars
+	}
+
+	return Item{
+		ID:          fmt.Sprintf("hfpapers:%s", paper.ID),
+		Source:      SourceHFPapers,
+		ExternalID:  paper.ID,
+		Title:       title,
+		URL:         hfBaseURL + "/papers/" + paper.ID,
+		Description: truncate(summary, 500),
+		Author:      author,
+		Score:       paper.Upvotes,
+		Comments:    p.NumComments,
+		Tags:        paper.AIKeywords,
+		PublishedAt: published.UTC(),
+		CollectedAt: time.Now().UTC(),
+		Extra:       extra,
+	}
+}
+
+type hfDailyPaper struct {
+	Paper       hfPaper   `json:"paper"`
+	Title       string    `json:"title"`
+	PublishedAt time.Time `json:"publishedAt"` // when it was featured
+	NumComments int       `json:"numComments"`
+	SubmittedBy struct {
+		User string `json:"user"`
+	} `json:"submittedBy"`
+	Organization *struct {
+		Name string `json:"name"`
+	} `json:"organization"`
+}
+
+type hfPaper struct {
+	ID          string    `json:"id"` // arXiv ID
+	Title       string    `json:"title"`
+	Summary     string    `json:"summary"`
+	AISummary   string    `json:"ai_summary"`
+	AIKeywords  []string  `json:"ai_keywords"`
+	Upvotes     int       `json:"upvotes"`
+	PublishedAt time.Time `json:"publishedAt"`
+	GithubRepo  string    `json:"githubRepo"`
+	GithubStars int       `json:"githubStars"`
+	Authors     []struct {
+		Name string `json:"name"`
+	} `json:"authors"`
+}
+
+// HFPapersConfig for the Hugging Face Daily Papers collector. It uses the
+// Hugging Face Hub token, if any.
+type HFPapersConfig struct {
+	Enabled bool `yaml:"enabled"`
+	Days    int  `yaml:"days"`  // daily lists to poll, counting back from today
+	Limit   int  `yaml:"limit"` // papers per day
+}
+
+func init() {
+	Register(Factory[HFPapersConfig]{
+		Type:    SourceHFPapers,
+		Aliases: []string{"papers"},
+		Defaults: func() HFPapersConfig {
+			return HFPapersConfig{Enabled: true, Days: 3, Limit: 50}
+		},
+		Enabled: func(c HFPapersConfig) bool { return c.Enabled },
+		New: func(c HFPapersConfig, deps Deps) ([]Source, error) {
+			hub, _ := Section[HuggingFaceConfig](deps.Config, SourceHuggingFace)
+			return []Source{NewHFPapers(hub.Token, c.Days, c.Limit)}, nil
+		},
+	})
+}
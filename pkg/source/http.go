@@ -0,0 +1,374 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Errors wrapped by collectors when a request fails, so callers can tell a
+// bad token from a busy or broken API with errors.Is.
+var (
+	ErrAuth        = errors.New("authentication failed")
+	ErrRateLimited = errors.New("rate limited")
+	ErrTransient   = errors.New("temporary failure")
+)
+
+const (
+	httpMaxAttempts = 4
+	httpBaseBackoff = 1 * time.Second
+	httpMaxBackoff  = 30 * time.Second
+	// httpMaxWait is the longest a request waits for a rate limit to reset.
+	// Longer limits (GitHub's hourly quota) fail with ErrRateLimited instead
+	// of stalling the whole collect run.
+	httpMaxWait = 60 * time.Second
+)
+
+// hostRate is a token bucket configuration: sustained requests per second
+// and the burst allowed on top.
+type hostRate struct {
+	perSecond float64
+	burst     int
+}
+
+// Per-host request rates, from each API's published limits. Hosts not
+// listed get defaultHostRate.
+var hostRates = map[string]hostRate{
+	"export.arxiv.org":           {1.0 / 3, 1}, // "no more than one request every three seconds"
+	"api.semanticscholar.org":    {1, 1},
+	"paperswithcode.com":         {2, 5},
+	"www.reddit.com":             {10.0 / 60, 2}, // unauthenticated: 10 per minute
+	"oauth.reddit.com":           {1.5, 5},       // 100 per minute
+	"api.github.com":             {1, 10},
+	"github.com":                 {1, 3},
+	"hacker-news.firebaseio.com": {20, 20},
+	"hn.algolia.com":             {5, 10},
+	"www.googleapis.com":         {5, 5},
+	"api2.openreview.net":        {1, 3},
+	"pypistats.org":              {1, 3},
+}
+
+var defaultHostRate = hostRate{perSecond: 5, burst: 10}
+
+// newHTTPClient returns a client for collectors. Its requests share per-host
+// rate limits with every other collector and are retried with exponential
+// backoff on network errors, 429 and 5xx responses, honoring Retry-After and
+// GitHub/Reddit rate-limit headers. timeout applies to each attempt.
+func newHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Transport: &retryTransport{
+			base:    http.DefaultTransport,
+			limits:  sharedHostLimits,
+			timeout: timeout,
+		},
+	}
+}
+
+// StatusError is an unsuccessful HTTP response. It unwraps to ErrAuth,
+// ErrRateLimited or ErrTransient when the status is one of those.
+type StatusError struct {
+	StatusCode int
+	RetryAfter time.Duration // when the server said how long to wait
+	kind       error
+}
+
+func (e *StatusError) Error() string {
+	msg := fmt.Sprintf("status %d", e.StatusCode)
+	if e.kind != nil {
+		msg += " (" + e.kind.Error()
+		if e.RetryAfter > 0 {
+			msg += ", retry in " + e.RetryAfter.Round(time.Second).String()
+		}
+		msg += ")"
+	}
+	return msg
+}
+
+func (e *StatusError) Unwrap() error { return e.kind }
+
+// checkStatus returns a *StatusError for any response other than 200 OK.
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	err := &StatusError{StatusCode: resp.StatusCode}
+	switch {
+	case isRateLimited(resp):
+		err.kind = ErrRateLimited
+		err.RetryAfter, _ = serverDelay(resp)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		err.kind = ErrAuth
+	case resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode >= 500:
+		err.kind = ErrTransient
+	}
+	return err
+}
+
+// isRateLimited reports whether a response is a rate limit rejection. GitHub
+// answers 403 rather than 429 when the quota is used up.
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if resp.StatusCode == http.StatusForbidden {
+		return resp.Header.Get("Retry-After") != "" || quotaExhausted(resp.Header)
+	}
+	return false
+}
+
+type retryTransport struct {
+	base    http.RoundTripper
+	limits  *hostLimits
+	timeout time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	host := req.URL.Host
+
+	for attempt := 1; ; attempt++ {
+		if err := t.limits.wait(ctx, host); err != nil {
+			return nil, err
+		}
+
+		r := req
+		if attempt > 1 {
+			r = req.Clone(ctx)
+			if req.Body != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				r.Body = body
+			}
+		}
+
+		resp, err := t.attempt(r)
+		if resp != nil {
+			t.limits.observe(host, resp.Header)
+		}
+
+		delay, retry := t.retryDelay(req, resp, err, attempt)
+		if !retry {
+			if err != nil && ctx.Err() == nil {
+				return nil, fmt.Errorf("%w: %w", ErrTransient, err)
+			}
+			return resp, err
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// attempt sends one request, bounded by the per-attempt timeout. The timeout
+// keeps running while the caller reads the body, like http.Client.Timeout.
+func (t *retryTransport) attempt(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// retryDelay decides whether a failed attempt is worth repeating and how
+// long to wait first.
+func (t *retryTransport) retryDelay(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if attempt >= httpMaxAttempts || req.Context().Err() != nil {
+		return 0, false
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return 0, false // body can't be replayed
+	}
+
+	if err != nil {
+		return backoff(attempt), true
+	}
+
+	switch {
+	case isRateLimited(resp):
+	case resp.StatusCode == http.StatusRequestTimeout,
+		resp.StatusCode == http.StatusInternalServerError,
+		resp.StatusCode == http.StatusBadGateway,
+		resp.StatusCode == http.StatusServiceUnavailable,
+		resp.StatusCode == http.StatusGatewayTimeout:
+	default:
+		return 0, false
+	}
+
+	if d, ok := serverDelay(resp); ok {
+		if d > httpMaxWait {
+			return 0, false
+		}
+		return d, true
+	}
+	return backoff(attempt), true
+}
+
+// backoff returns an exponential delay with jitter: 0.5-1s, 1-2s, 2-4s, ...
+func backoff(attempt int) time.Duration {
+	d := min(httpBaseBackoff<<(attempt-1), httpMaxBackoff)
+	return d/2 + rand.N(d/2+1)
+}
+
+// serverDelay reads how long the server wants us to wait, from Retry-After
+// or an exhausted rate-limit quota.
+func serverDelay(resp *http.Response) (time.Duration, bool) {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+			return time.Duration(secs) * time.Second, true
+		}
+		if at, err := http.ParseTime(v); err == nil {
+			return max(time.Until(at), 0), true
+		}
+	}
+	if quotaExhausted(resp.Header) {
+		if reset, ok := quotaReset(resp.Header); ok {
+			return max(time.Until(reset), 0), true
+		}
+	}
+	return 0, false
+}
+
+// quotaExhausted reports whether rate-limit headers say no requests are
+// left: X-RateLimit-Remaining (GitHub, "0") or X-Ratelimit-Remaining
+// (Reddit, "0.0").
+func quotaExhausted(h http.Header) bool {
+	v := h.Get("X-RateLimit-Remaining")
+	if v == "" {
+		return false
+	}
+	remaining, err := strconv.ParseFloat(v, 64)
+	return err == nil && remaining < 1
+}
+
+// quotaReset returns when the quota refills. GitHub sends a Unix timestamp,
+// Reddit the seconds until reset.
+func quotaReset(h http.Header) (time.Time, bool) {
+	v := h.Get("X-RateLimit-Reset")
+	if v == "" {
+		return time.Time{}, false
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	if n > 1e9 {
+		return time.Unix(int64(n), 0), true
+	}
+	return time.Now().Add(time.Duration(n * float64(time.Second))), true
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// hostLimits holds a token bucket per host, shared by all collectors.
+type hostLimits struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+var sharedHostLimits = &hostLimits{buckets: make(map[string]*tokenBucket)}
+
+type tokenBucket struct {
+	rate        hostRate
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time // set when the server reports an exhausted quota
+}
+
+func (l *hostLimits) bucket(host string) *tokenBucket {
+	b, ok := l.buckets[host]
+	if !ok {
+		rate, ok := hostRates[host]
+		if !ok {
+			rate = defaultHostRate
+		}
+		b = &tokenBucket{rate: rate, tokens: float64(rate.burst), last: time.Now()}
+		l.buckets[host] = b
+	}
+	return b
+}
+
+// wait blocks until a request to host may be sent. It fails right away with
+// ErrRateLimited when the host's quota resets too far in the future.
+func (l *hostLimits) wait(ctx context.Context, host string) error {
+	l.mu.Lock()
+	b := l.bucket(host)
+	now := time.Now()
+
+	if pause := b.pausedUntil.Sub(now); pause > httpMaxWait {
+		l.mu.Unlock()
+		return fmt.Errorf("%s: %w until %s", host, ErrRateLimited, b.pausedUntil.Format(time.Kitchen))
+	}
+
+	// Reserve a token; a negative balance is the queue of waiting requests.
+	b.tokens = min(b.tokens+now.Sub(b.last).Seconds()*b.rate.perSecond, float64(b.rate.burst))
+	b.last = now
+	b.tokens--
+	delay := max(b.pausedUntil.Sub(now), 0)
+	if b.tokens < 0 {
+		delay = max(delay, time.Duration(-b.tokens/b.rate.perSecond*float64(time.Second)))
+	}
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return nil
+	}
+}
+
+// observe pauses a host when its rate-limit headers report an exhausted
+// quota. GitHub's secondary quotas (search, code search) are left to the
+// per-request retry so they don't block the core API.
+func (l *hostLimits) observe(host string, h http.Header) {
+	if !quotaExhausted(h) {
+		return
+	}
+	if resource := h.Get("X-RateLimit-Resource"); resource != "" && resource != "core" {
+		return
+	}
+	reset, ok := quotaReset(h)
+	if !ok {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if b := l.bucket(host); reset.After(b.pausedUntil) {
+		b.pausedUntil = reset
+	}
+}
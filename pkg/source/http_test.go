@@ -0,0 +1,275 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func response(status int, headers ...string) *http.Response {
+	resp := &http.Response{StatusCode: status, Header: make(http.Header)}
+	for i := 0; i+1 < len(headers); i += 2 {
+		resp.Header.Set(headers[i], headers[i+1])
+	}
+	return resp
+}
+
+func TestServerDelay(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		resp   *http.Response
+		want   time.Duration
+		wantOK bool
+	}{
+		{"no headers", response(429), 0, false},
+		{"retry-after seconds", response(429, "Retry-After", "7"), 7 * time.Second, true},
+		{"retry-after date", response(503, "Retry-After", now.Add(30*time.Second).UTC().Format(http.TimeFormat)), 30 * time.Second, true},
+		{"retry-after date in the past", response(503, "Retry-After", now.Add(-time.Minute).UTC().Format(http.TimeFormat)), 0, true},
+		{"retry-after garbage", response(429, "Retry-After", "soon"), 0, false},
+		{
+			"github exhausted quota",
+			response(403, "X-RateLimit-Remaining", "0", "X-RateLimit-Reset", strconv.FormatInt(now.Add(45*time.Second).Unix(), 10)),
+			45 * time.Second, true,
+		},
+		{
+			"github quota left",
+			response(403, "X-RateLimit-Remaining", "12", "X-RateLimit-Reset", strconv.FormatInt(now.Add(45*time.Second).Unix(), 10)),
+			0, false,
+		},
+		{"reddit fractional headers", response(429, "X-Ratelimit-Remaining", "0.0", "X-Ratelimit-Reset", "12.5"), 12500 * time.Millisecond, true},
+		{"reddit fractional quota left", response(429, "X-Ratelimit-Remaining", "3.0", "X-Ratelimit-Reset", "12.5"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := serverDelay(tt.resp)
+			if ok != tt.wantOK {
+				t.Fatalf("serverDelay ok = %v, want %v", ok, tt.wantOK)
+			}
+			// HTTP dates and Unix timestamps have second precision.
+			if diff := got - tt.want; diff < -1500*time.Millisecond || diff > 1500*time.Millisecond {
+				t.Fatalf("serverDelay = %s, want about %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *http.Response
+		want error // nil = no kind
+	}{
+		{"unauthorized", response(401), ErrAuth},
+		{"forbidden", response(403), ErrAuth},
+		{"github exhausted quota", response(403, "X-RateLimit-Remaining", "0"), ErrRateLimited},
+		{"forbidden with retry-after", response(403, "Retry-After", "60"), ErrRateLimited},
+		{"too many requests", response(429), ErrRateLimited},
+		{"request timeout", response(408), ErrTransient},
+		{"server error", response(500), ErrTransient},
+		{"unavailable", response(503), ErrTransient},
+		{"not found", response(404), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkStatus(tt.resp)
+			var se *StatusError
+			if !errors.As(err, &se) || se.StatusCode != tt.resp.StatusCode {
+				t.Fatalf("checkStatus = %v, want a *StatusError for %d", err, tt.resp.StatusCode)
+			}
+			for _, kind := range []error{ErrAuth, ErrRateLimited, ErrTransient} {
+				if errors.Is(err, kind) != (kind == tt.want) {
+					t.Errorf("errors.Is(%v, %v) = %v", err, kind, !(kind == tt.want))
+				}
+			}
+		})
+	}
+
+	if err := checkStatus(response(200)); err != nil {
+		t.Fatalf("checkStatus(200) = %v", err)
+	}
+	if err := checkStatus(response(429, "Retry-After", "30")); !errors.Is(err, ErrRateLimited) || err.(*StatusError).RetryAfter != 30*time.Second {
+		t.Fatalf("checkStatus(429) = %v, want RetryAfter 30s", err)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tr := &retryTransport{}
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com/", nil)
+	quotaReset := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+
+	tests := []struct {
+		name      string
+		resp      *http.Response
+		err       error
+		attempt   int
+		wantRetry bool
+		min, max  time.Duration
+	}{
+		{"network error", nil, errors.New("connection reset"), 1, true, 500 * time.Millisecond, time.Second},
+		{"network error, third attempt", nil, errors.New("connection reset"), 3, true, 2 * time.Second, 4 * time.Second},
+		{"last attempt", response(503), nil, httpMaxAttempts, false, 0, 0},
+		{"server error backs off", response(502), nil, 2, true, time.Second, 2 * time.Second},
+		{"retry-after", response(429, "Retry-After", "3"), nil, 1, true, 3 * time.Second, 3 * time.Second},
+		{"retry-after too long", response(429, "Retry-After", "3600"), nil, 1, false, 0, 0},
+		{"github hourly quota", response(403, "X-RateLimit-Remaining", "0", "X-RateLimit-Reset", quotaReset), nil, 1, false, 0, 0},
+		{"reddit short quota", response(429, "X-Ratelimit-Remaining", "0.0", "X-Ratelimit-Reset", "2.0"), nil, 1, true, time.Second, 2 * time.Second},
+		{"bad token", response(401), nil, 1, false, 0, 0},
+		{"not found", response(404), nil, 1, false, 0, 0},
+		{"not implemented", response(501), nil, 1, false, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, retry := tr.retryDelay(req, tt.resp, tt.err, tt.attempt)
+			if retry != tt.wantRetry {
+				t.Fatalf("retry = %v, want %v", retry, tt.wantRetry)
+			}
+			if retry && (delay < tt.min || delay > tt.max) {
+				t.Fatalf("delay = %s, want %s-%s", delay, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/flaky":
+			if calls.Add(1) < 3 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		case "/missing":
+			calls.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	client := newHTTPClient(5 * time.Second)
+
+	resp, err := client.Get(srv.URL + "/flaky")
+	if err != nil {
+		t.Fatalf("GET /flaky: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls.Load() != 3 {
+		t.Fatalf("GET /flaky: status %d after %d calls, want 200 after 3", resp.StatusCode, calls.Load())
+	}
+
+	calls.Store(0)
+	resp, err = client.Get(srv.URL + "/missing")
+	if err != nil {
+		t.Fatalf("GET /missing: %v", err)
+	}
+	resp.Body.Close()
+	if calls.Load() != 1 {
+		t.Fatalf("GET /missing: %d calls, want 1 (not retried)", calls.Load())
+	}
+	if err := checkStatus(resp); err == nil || errors.Is(err, ErrTransient) {
+		t.Fatalf("checkStatus(404) = %v", err)
+	}
+}
+
+func TestHostLimitsWait(t *testing.T) {
+	ctx := context.Background()
+	newLimits := func(rate hostRate) *hostLimits {
+		return &hostLimits{buckets: map[string]*tokenBucket{
+			"api.test": {rate: rate, tokens: float64(rate.burst), last: time.Now()},
+		}}
+	}
+
+	t.Run("burst then rate", func(t *testing.T) {
+		l := newLimits(hostRate{perSecond: 20, burst: 2})
+		start := time.Now()
+		for i := 0; i < 2; i++ {
+			if err := l.wait(ctx, "api.test"); err != nil {
+				t.Fatalf("wait: %v", err)
+			}
+		}
+		if d := time.Since(start); d > 20*time.Millisecond {
+			t.Fatalf("burst of 2 took %s", d)
+		}
+		// Two more requests: one token every 50ms.
+		for i := 0; i < 2; i++ {
+			if err := l.wait(ctx, "api.test"); err != nil {
+				t.Fatalf("wait: %v", err)
+			}
+		}
+		if d := time.Since(start); d < 90*time.Millisecond || d > 500*time.Millisecond {
+			t.Fatalf("4 requests at 20/s with burst 2 took %s, want about 100ms", d)
+		}
+	})
+
+	t.Run("other hosts unaffected", func(t *testing.T) {
+		l := newLimits(hostRate{perSecond: 0.1, burst: 1})
+		l.wait(ctx, "api.test")
+		start := time.Now()
+		if err := l.wait(ctx, "other.test"); err != nil {
+			t.Fatalf("wait: %v", err)
+		}
+		if d := time.Since(start); d > 20*time.Millisecond {
+			t.Fatalf("unrelated host waited %s", d)
+		}
+	})
+
+	t.Run("cancelled while queued", func(t *testing.T) {
+		l := newLimits(hostRate{perSecond: 0.1, burst: 1})
+		l.wait(ctx, "api.test")
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		if err := l.wait(ctx, "api.test"); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("wait = %v, want deadline exceeded", err)
+		}
+	})
+
+	t.Run("exhausted quota fails fast", func(t *testing.T) {
+		l := newLimits(hostRate{perSecond: 10, burst: 10})
+		l.observe("api.test", http.Header{
+			"X-Ratelimit-Remaining": {"0"},
+			"X-Ratelimit-Reset":     {strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)},
+		})
+		if err := l.wait(ctx, "api.test"); !errors.Is(err, ErrRateLimited) {
+			t.Fatalf("wait = %v, want ErrRateLimited", err)
+		}
+	})
+
+	t.Run("secondary quota doesn't pause the host", func(t *testing.T) {
+		l := newLimits(hostRate{perSecond: 10, burst: 10})
+		l.observe("api.test", http.Header{
+			"X-Ratelimit-Remaining": {"0"},
+			"X-Ratelimit-Reset":     {strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)},
+			"X-Ratelimit-Resource":  {"search"},
+		})
+		if err := l.wait(ctx, "api.test"); err != nil {
+			t.Fatalf("wait = %v", err)
+		}
+	})
+
+	t.Run("short pause is waited out", func(t *testing.T) {
+		l := newLimits(hostRate{perSecond: 10, burst: 10})
+		l.observe("api.test", http.Header{
+			"X-Ratelimit-Remaining": {"0.0"},
+			"X-Ratelimit-Reset":     {"0.1"},
+		})
+		start := time.Now()
+		if err := l.wait(ctx, "api.test"); err != nil {
+			t.Fatalf("wait: %v", err)
+		}
+		if d := time.Since(start); d < 50*time.Millisecond {
+			t.Fatalf("paused host waited only %s", d)
+		}
+	})
+}
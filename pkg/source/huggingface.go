@@ -0,0 +1,197 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const hfBaseURL = "https://huggingface.co"
+
+// HuggingFace collects trending models, datasets and Spaces from the Hugging Face Hub.
+type HuggingFace struct {
+	client *http.Client
+	token  string
+	kinds  []string
+	limit  int
+}
+
+// NewHuggingFace creates a new Hugging Face Hub collector.
+// kinds selects which listings to poll: "models", "datasets" and/or "spaces".
+func NewHuggingFace(token string, kinds []string, limit int) *HuggingFace {
+	if len(kinds) == 0 {
+		kinds = []string{"models", "datasets", "spaces"}
+	}
+	if limit <= 0 {
+		limit = 30
+	}
+	return &HuggingFace{
+		client: newHTTPClient(30 * time.Second),
+		token:  token,
+		kinds:  kinds,
+		limit:  limit,
+	}
+}
+
+func (h *HuggingFace) Name() SourceType { return SourceHuggingFace }
+
+func (h *HuggingFace) Collect(ctx context.Context) ([]Item, error) {
+	var allItems []Item
+
+	for _, kind := range h.kinds {
+		items, err := h.fetchTrending(ctx, kind)
+		if err != nil {
+			fmt.Printf("  huggingface %s error: %v\n", kind, err)
+			continue
+		}
+		allItems = append(allItems, items...)
+	}
+
+	return allItems, nil
+}
+
+func (h *HuggingFace) fetchTrending(ctx context.Context, kind string) ([]Item, error) {
+	var urlPrefix, idPrefix string
+	switch kind {
+	case "models":
+		urlPrefix, idPrefix = hfBaseURL+"/", "model"
+	case "datasets":
+		urlPrefix, idPrefix = hfBaseURL+"/datasets/", "dataset"
+	case "spaces":
+		urlPrefix, idPrefix = hfBaseURL+"/spaces/", "space"
+	default:
+		return nil, fmt.Errorf("unknown kind %q", kind)
+	}
+
+	params := url.Values{}
+	params.Set("sort", "trendingScore")
+	params.Set("direction", "-1")
+	params.Set("limit", strconv.Itoa(h.limit))
+
+	reqURL := fmt.Sprintf("%s/api/%s?%s", hfBaseURL, kind, params.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create huggingface request: %w", err)
+	}
+	req.Header.Set("User-Agent", "airadar/1.0")
+	if h.token != "" {
+		req.Header.Set("Authorization", "Bearer "+h.token)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch huggingface %s: %w", kind, err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return nil, fmt.Errorf("huggingface %s: %w", kind, err)
+	}
+
+	var repos []hfRepo
+	if err := json.NewDecoder(resp.Body).Decode(&repos); err != nil {
+		return nil, fmt.Errorf("decode huggingface %s: %w", kind, err)
+	}
+
+	var items []Item
+	for _, repo := range repos {
+		if repo.Private {
+			continue
+		}
+
+		// Pipeline tag (e.g. "text-generation") for models, SDK for Spaces.
+		var tags []string
+		if repo.PipelineTag != "" {
+			tags = append(tags, repo.PipelineTag)
+		}
+		if repo.SDK != "" {
+			tags = append(tags, repo.SDK)
+		}
+		tags = append(tags, idPrefix)
+
+		author := repo.Author
+		if author == "" {
+			author, _, _ = strings.Cut(repo.ID, "/")
+		}
+
+		published := repo.CreatedAt
+		if published.IsZero() {
+			published = time.Now().UTC()
+		}
+
+		externalID := idPrefix + ":" + repo.ID
+		items = append(items, Item{
+			ID:          fmt.Sprintf("huggingface:%s", externalID),
+			Source:      SourceHuggingFace,
+			ExternalID:  externalID,
+			Title:       repo.ID,
+			URL:         urlPrefix + repo.ID,
+			Description: truncate(repo.Description, 500),
+			Author:      author,
+			Score:       repo.Likes,
+			Tags:        tags,
+			PublishedAt: published,
+			CollectedAt: time.Now().UTC(),
+			Extra: map[string]any{
+				"kind":           idPrefix,
+				"likes":          repo.Likes,
+				"downloads":      repo.Downloads,
+				"trending_score": repo.TrendingScore,
+				"library":        repo.LibraryName,
+			},
+		})
+	}
+
+	return items, nil
+}
+
+type hfRepo struct {
+	ID            string    `json:"id"`
+	Author        string    `json:"author"`
+	Description   string    `json:"description"`
+	Likes         int       `json:"likes"`
+	Downloads     int       `json:"downloads"`
+	TrendingScore float64   `json:"trendingScore"`
+	PipelineTag   string    `json:"pipeline_tag"`
+	LibraryName   string    `json:"library_name"`
+	SDK           string    `json:"sdk"`
+	Private       bool      `json:"private"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// HuggingFaceConfig for Hugging Face Hub collector.
+type HuggingFaceConfig struct {
+	Enabled bool     `yaml:"enabled"`
+	Token   string   `yaml:"token"`
+	Kinds   []string `yaml:"kinds"` // "models", "datasets", "spaces"
+	Limit   int      `yaml:"limit"` // per kind
+}
+
+func init() {
+	Register(Factory[HuggingFaceConfig]{
+		Type:    SourceHuggingFace,
+		Aliases: []string{"hf"},
+		Defaults: func() HuggingFaceConfig {
+			return HuggingFaceConfig{
+				Enabled: true,
+				Kinds:   []string{"models", "datasets", "spaces"},
+				Limit:   30,
+			}
+		},
+		Env: func(c *HuggingFaceConfig) {
+			if v := os.Getenv("HF_TOKEN"); v != "" {
+				c.Token = v
+			}
+		},
+		Enabled: func(c HuggingFaceConfig) bool { return c.Enabled },
+		New: func(c HuggingFaceConfig, deps Deps) ([]Source, error) {
+			return []Source{NewHuggingFace(c.Token, c.Kinds, c.Limit)}, nil
+		},
+	})
+}
@@ -0,0 +1,169 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Instance is a named collector built from a config section or one entry of
+// a section written as a list. A source type can run several instances with
+// different settings, e.g. an RSS group of research blogs and one of press
+// feeds. Items are stamped with the instance name.
+type Instance struct {
+	Source
+
+	Type     SourceType    // registered type that built it, e.g. json_api
+	Interval time.Duration // collection interval; 0 = the global one
+	Weight   float64       // trend score multiplier for its items
+
+	name    string
+	cursors *Cursors   // nil for collectors without cursors
+	mu      sync.Mutex // held from Collect through Commit by CollectAndStore
+}
+
+// InstanceName returns the instance's name, unique across all sources.
+func (i *Instance) InstanceName() string { return i.name }
+
+// Rewind makes the next collections start from since instead of the
+// persisted cursors, e.g. to backfill a gap. Cursors still advance.
+func (i *Instance) Rewind(since time.Time) {
+	if i.cursors != nil {
+		i.cursors.since = since
+	}
+}
+
+func (i *Instance) Collect(ctx context.Context) ([]Item, error) {
+	if i.cursors != nil {
+		i.cursors.discard()
+	}
+	items, err := i.Source.Collect(ctx)
+	for k := range items {
+		items[k].Instance = i.name
+	}
+	return items, err
+}
+
+// Commit stores the cursors of the last Collect and commits its source,
+// once the collected items are stored.
+func (i *Instance) Commit(ctx context.Context) error {
+	var err error
+	if i.cursors != nil {
+		err = i.cursors.commit(ctx)
+	}
+	return errors.Join(err, Commit(ctx, i.Source))
+}
+
+// CollectAndStore collects from src, saves the items with save and then
+// commits src, so items that fail to save are collected again next time.
+// Collections of one instance run one at a time, e.g. a scheduled run and
+// one requested through the API, so neither drops or commits the cursors
+// the other staged. Items are returned with a commit error, as they were
+// saved.
+func CollectAndStore(ctx context.Context, src Source, save func(context.Context, []Item) error) ([]Item, error) {
+	inst := InstanceOf(src)
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+
+	items, err := inst.Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := save(ctx, items); err != nil {
+		return nil, fmt.Errorf("store: %w", err)
+	}
+	if err := inst.Commit(ctx); err != nil {
+		return items, fmt.Errorf("commit: %w", err)
+	}
+	return items, nil
+}
+
+// named is implemented by sources that carry a configured name, such as
+// json_api and exec sources.
+type named interface {
+	InstanceName() string
+}
+
+// InstanceOf returns src as an instance. Sources built outside the registry
+// are treated as a default instance named after their type.
+func InstanceOf(src Source) *Instance {
+	if inst, ok := src.(*Instance); ok {
+		return inst
+	}
+	return &Instance{Source: src, Type: src.Name(), Weight: 1, name: defaultInstanceName(src)}
+}
+
+// defaultInstanceName is a source's configured name, or its type.
+func defaultInstanceName(src Source) string {
+	if n, ok := src.(named); ok && n.InstanceName() != "" {
+		return n.InstanceName()
+	}
+	return string(src.Name())
+}
+
+// instanceSettings are the keys every source section and instance accepts
+// next to its own config.
+type instanceSettings struct {
+	Name     string   `yaml:"name"`
+	Interval string   `yaml:"interval"` // e.g. "1h" (default: schedule.collect_interval)
+	Weight   *float64 `yaml:"weight"`   // trend score multiplier (default: 1; 0 mutes the instance)
+	Filter   struct {
+		ExtraKeywords   []string `yaml:"extra_keywords"`
+		ExcludeKeywords []string `yaml:"exclude_keywords"`
+	} `yaml:"filter"` // added to the global filter
+}
+
+func (s instanceSettings) validate() error {
+	if s.Interval != "" {
+		if d, err := time.ParseDuration(s.Interval); err != nil || d <= 0 {
+			return fmt.Errorf("invalid interval %q", s.Interval)
+		}
+	}
+	if s.Weight != nil && *s.Weight < 0 {
+		return fmt.Errorf("negative weight %v", *s.Weight)
+	}
+	return nil
+}
+
+// instance wraps a source built from these settings.
+func (s instanceSettings) instance(src Source, t SourceType) *Instance {
+	name := s.Name
+	if name == "" {
+		name = defaultInstanceName(src)
+	}
+	interval, _ := time.ParseDuration(s.Interval)
+	weight := 1.0
+	if s.Weight != nil {
+		weight = *s.Weight
+	}
+	return &Instance{Source: src, Type: t, Interval: interval, Weight: weight, name: name}
+}
+
+// instanceUser is implemented by collectors that keep state in the store
+// per instance, such as imported feeds or their own items. The registry
+// hands them their instance name.
+type instanceUser interface {
+	setInstance(name string)
+}
+
+// attach hands the collector its instance name and, if it keeps cursors,
+// those of its instance.
+func (i *Instance) attach(store CursorStore) {
+	if u, ok := i.Source.(instanceUser); ok {
+		u.setInstance(i.name)
+	}
+	if c, ok := i.Source.(cursorUser); ok {
+		i.cursors = newCursors(store, i.name)
+		c.setCursors(i.cursors)
+	}
+}
+
+// filter returns the global filter extended with the instance's keywords.
+func (s instanceSettings) filter(global *Filter) *Filter {
+	if len(s.Filter.ExtraKeywords) == 0 && len(s.Filter.ExcludeKeywords) == 0 {
+		return global
+	}
+	return global.With(s.Filter.ExtraKeywords, s.Filter.ExcludeKeywords)
+}
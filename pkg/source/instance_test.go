@@ -0,0 +1,184 @@
+package source
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestListFormInstances(t *testing.T) {
+	sources, err := buildFromYAML(t, `
+registry_probe:
+  - name: fast
+    enabled: true
+    limit: 3
+    interval: 15m
+    weight: 2
+  - name: muted
+    enabled: true
+    weight: 0
+  - name: off
+    enabled: false
+`)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	got := probes(sources)
+	if len(got) != 2 {
+		t.Fatalf("built %d probes, want fast and muted", len(got))
+	}
+	fast, muted := got[0], got[1]
+	if fast.InstanceName() != "fast" || fast.Interval.Minutes() != 15 || fast.Weight != 2 {
+		t.Errorf("fast = %s every %s weight %v", fast.InstanceName(), fast.Interval, fast.Weight)
+	}
+	// Instances start from the defaults, not from each other.
+	if c := fast.Source.(*probeSource).cfg; c.Limit != 3 || c.Tags[0] != "default" {
+		t.Errorf("fast config = %+v", c)
+	}
+	if c := muted.Source.(*probeSource).cfg; c.Limit != 10 {
+		t.Errorf("muted config = %+v, want the default limit", c)
+	}
+	if muted.InstanceName() != "muted" || muted.Weight != 0 || muted.Interval != 0 {
+		t.Errorf("muted = %s every %s weight %v, want weight 0", muted.InstanceName(), muted.Interval, muted.Weight)
+	}
+
+	if sel := Select(sources, []string{"MUTED"}); len(sel) != 1 || InstanceOf(sel[0]).InstanceName() != "muted" {
+		t.Errorf("Select(MUTED) = %d sources", len(sel))
+	}
+	if sel := Select(sources, []string{"probe"}); len(sel) != 2 {
+		t.Errorf("Select(probe) = %d sources, want both instances", len(sel))
+	}
+}
+
+func TestInstanceWeightDefault(t *testing.T) {
+	sources, err := buildFromYAML(t, "registry_probe:\n  - {name: plain, enabled: true}")
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if got := probes(sources); len(got) != 1 || got[0].Weight != 1 {
+		t.Fatalf("unset weight = %v, want 1", got[0].Weight)
+	}
+	if w := InstanceOf(&probeSource{}).Weight; w != 1 {
+		t.Fatalf("unregistered source weight = %v, want 1", w)
+	}
+}
+
+func TestInstanceErrors(t *testing.T) {
+	tests := []struct {
+		name, doc, wantErr string
+	}{
+		{
+			name:    "instance without a name",
+			doc:     "registry_probe:\n  - {enabled: true}",
+			wantErr: "[0]: instances need a name",
+		},
+		{
+			name:    "negative instance weight",
+			doc:     "registry_probe:\n  - {name: a, weight: -0.5}",
+			wantErr: "[0]: negative weight -0.5",
+		},
+		{
+			name:    "bad instance interval",
+			doc:     "registry_probe:\n  - {name: a}\n  - {name: b, interval: 0s}",
+			wantErr: `[1]: invalid interval "0s"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg Config
+			err := yaml.Unmarshal([]byte(tt.doc), &cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("decode = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDuplicateInstanceNames(t *testing.T) {
+	tests := []struct {
+		name, doc, dup string
+		want           int // probes still built
+	}{
+		{
+			name: "within a section",
+			doc:  "registry_probe:\n  - {name: twin, enabled: true}\n  - {name: Twin, enabled: true}",
+			dup:  "Twin",
+			want: 1,
+		},
+		{
+			name: "instance named after another source",
+			doc:  "hackernews:\n  enabled: true\nregistry_probe:\n  - {name: hackernews, enabled: true}",
+			dup:  "hackernews",
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sources, err := buildFromYAML(t, tt.doc)
+			want := `source instance "` + tt.dup + `" defined twice`
+			if err == nil || !strings.Contains(err.Error(), want) {
+				t.Fatalf("Build error = %v, want %q", err, want)
+			}
+			if got := probes(sources); len(got) != tt.want {
+				t.Fatalf("built %d probes, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+// countingSource emits one item per run, numbered from its cursor.
+type countingSource struct{ cursors *Cursors }
+
+func (c *countingSource) Name() SourceType        { return sourceProbe }
+func (c *countingSource) setCursors(cur *Cursors) { c.cursors = cur }
+
+func (c *countingSource) Collect(ctx context.Context) ([]Item, error) {
+	last, err := c.cursors.Get(ctx, "last")
+	if err != nil {
+		return nil, err
+	}
+	n, _ := strconv.Atoi(last)
+	n++
+	c.cursors.Set(ctx, "last", strconv.Itoa(n))
+	return []Item{{ID: "probe:" + strconv.Itoa(n)}}, nil
+}
+
+func TestCollectAndStoreSerializesInstance(t *testing.T) {
+	store := &mapCursorStore{values: map[string]string{}}
+	inst := &Instance{Source: &countingSource{}, name: "counter"}
+	inst.attach(store)
+
+	var (
+		mu    sync.Mutex
+		saved = make(map[string]bool)
+		wg    sync.WaitGroup
+	)
+	for range 10 {
+		wg.Go(func() {
+			_, err := CollectAndStore(context.Background(), inst, func(_ context.Context, items []Item) error {
+				time.Sleep(time.Millisecond) // let other collections overlap
+				mu.Lock()
+				defer mu.Unlock()
+				for _, it := range items {
+					saved[it.ID] = true
+				}
+				return nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	wg.Wait()
+
+	// Each run continued from the cursor the previous one committed.
+	if len(saved) != 10 || store.values["counter/last"] != "10" {
+		t.Fatalf("saved %d distinct items, cursor %q, want 10 and 10", len(saved), store.values["counter/last"])
+	}
+}
@@ -0,0 +1,456 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONAPISpec declares a JSON API source entirely from configuration.
+type JSONAPISpec struct {
+	Name    string
+	Type    SourceType // defaults to Name
+	URL     string
+	Headers map[string]string // values may reference env vars as ${VAR}
+
+	// ItemsPath locates the array of items in the response ("" = root).
+	ItemsPath  string
+	Pagination JSONAPIPagination
+	Fields     JSONAPIFields
+	Filter     bool // apply the AI keyword filter to title + description
+}
+
+// JSONAPIPagination describes how to request subsequent pages.
+//
+//	page:     increment query param Param starting at Start (default 1)
+//	offset:   set query param Param to Start (default 0) plus the number of
+//	          items seen so far
+//	cursor:   set query param Param to the value found at Path in the response
+//	next_url: follow the URL found at Path in the response
+//
+// Without Start, the first request leaves Param to the API's default.
+type JSONAPIPagination struct {
+	Type     string `yaml:"type"`
+	Param    string `yaml:"param"`
+	Path     string `yaml:"path"`
+	Start    *int   `yaml:"start"`
+	MaxPages int    `yaml:"max_pages"`
+}
+
+// JSONAPIFields maps item fields to paths inside each element of the item
+// array. Paths use dot notation with optional indices: "user.name",
+// "links[0].href", "$.stats.points".
+type JSONAPIFields struct {
+	ID          string `yaml:"id"`
+	Title       string `yaml:"title"`
+	URL         string `yaml:"url"`
+	Description string `yaml:"description"`
+	Author      string `yaml:"author"`
+	Score       string `yaml:"score"`
+	Comments    string `yaml:"comments"`
+	PublishedAt string `yaml:"published_at"`
+	Tags        string `yaml:"tags"`
+}
+
+// JSONAPI collects items from an arbitrary JSON API described by a JSONAPISpec.
+type JSONAPI struct {
+	client *http.Client
+	spec   JSONAPISpec
+	filter *Filter
+}
+
+// NewJSONAPI creates a collector for a declarative JSON API source.
+func NewJSONAPI(spec JSONAPISpec, filter *Filter) *JSONAPI {
+	if spec.Type == "" {
+		spec.Type = SourceType(spec.Name)
+	}
+	if spec.Pagination.MaxPages <= 0 {
+		spec.Pagination.MaxPages = 1
+	}
+	if spec.Fields.ID == "" {
+		spec.Fields.ID = "id"
+	}
+	if spec.Fields.Title == "" {
+		spec.Fields.Title = "title"
+	}
+	return &JSONAPI{
+		client: newHTTPClient(30 * time.Second),
+		spec:   spec,
+		filter: filter,
+	}
+}
+
+func (j *JSONAPI) Name() SourceType { return j.spec.Type }
+
+// InstanceName is the configured name, which several APIs sharing one type
+// keep apart.
+func (j *JSONAPI) InstanceName() string { return j.spec.Name }
+
+func (j *JSONAPI) Collect(ctx context.Context) ([]Item, error) {
+	spec := j.spec
+	pg := spec.Pagination
+
+	nextURL := spec.URL
+	start := 1
+	if pg.Type == "offset" {
+		start = 0
+	}
+	if pg.Start != nil {
+		start = *pg.Start
+	}
+	page, seen := start, 0
+
+	var items []Item
+	for i := 0; i < pg.MaxPages && nextURL != ""; i++ {
+		reqURL, err := url.Parse(nextURL)
+		if err != nil {
+			return nil, fmt.Errorf("%s: parse url: %w", spec.Name, err)
+		}
+
+		if i > 0 || pg.Start != nil {
+			q := reqURL.Query()
+			switch pg.Type {
+			case "page":
+				q.Set(paramOr(pg.Param, "page"), strconv.Itoa(page))
+			case "offset":
+				q.Set(paramOr(pg.Param, "offset"), strconv.Itoa(start+seen))
+			}
+			reqURL.RawQuery = q.Encode()
+		}
+
+		body, err := j.fetch(ctx, reqURL.String())
+		if err != nil {
+			return nil, err
+		}
+
+		raw, ok := jsonPath(body, spec.ItemsPath)
+		if !ok {
+			return nil, fmt.Errorf("%s: items path %q not found", spec.Name, spec.ItemsPath)
+		}
+		elems, ok := raw.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: items path %q is not an array", spec.Name, spec.ItemsPath)
+		}
+		if len(elems) == 0 {
+			break
+		}
+
+		for _, elem := range elems {
+			if item, ok := j.mapItem(elem); ok {
+				items = append(items, item)
+			}
+		}
+
+		seen += len(elems)
+		page++
+
+		// Work out the next request.
+		switch pg.Type {
+		case "page", "offset":
+			// Same URL, query param updated at the top of the loop.
+		case "cursor":
+			cursor := jsonString(body, pg.Path)
+			if cursor == "" {
+				nextURL = ""
+				break
+			}
+			u, _ := url.Parse(spec.URL)
+			q := u.Query()
+			q.Set(paramOr(pg.Param, "cursor"), cursor)
+			u.RawQuery = q.Encode()
+			nextURL = u.String()
+		case "next_url":
+			nextURL = ""
+			if next := jsonString(body, pg.Path); next != "" {
+				if u, err := reqURL.Parse(next); err == nil {
+					nextURL = u.String()
+				}
+			}
+		default:
+			nextURL = ""
+		}
+	}
+
+	return items, nil
+}
+
+func (j *JSONAPI) fetch(ctx context.Context, reqURL string) (any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create %s request: %w", j.spec.Name, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "airadar/1.0")
+	for k, v := range j.spec.Headers {
+		req.Header.Set(k, os.ExpandEnv(v))
+	}
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", j.spec.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return nil, fmt.Errorf("%s: %w", j.spec.Name, err)
+	}
+
+	var body any
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", j.spec.Name, err)
+	}
+	return body, nil
+}
+
+// mapItem converts one element of the item array into an Item. Elements
+// without an ID or title are skipped.
+func (j *JSONAPI) mapItem(elem any) (Item, bool) {
+	f := j.spec.Fields
+
+	id := jsonString(elem, f.ID)
+	title := jsonString(elem, f.Title)
+	if id == "" || title == "" {
+		return Item{}, false
+	}
+
+	description := jsonString(elem, f.Description)
+	if j.spec.Filter && j.filter != nil && !j.filter.MatchesAI(title+" "+description) {
+		return Item{}, false
+	}
+
+	published := time.Now().UTC()
+	if t, ok := parseTimeValue(jsonField(elem, f.PublishedAt)); ok {
+		published = t
+	}
+
+	var tags []string
+	switch v := jsonField(elem, f.Tags).(type) {
+	case []any:
+		for _, t := range v {
+			if s := jsonScalar(t); s != "" {
+				tags = append(tags, s)
+			}
+		}
+	case string:
+		for _, t := range strings.Split(v, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				tags = append(tags, t)
+			}
+		}
+	}
+
+	return Item{
+		ID:          fmt.Sprintf("%s:%s", j.spec.Type, id),
+		Source:      j.spec.Type,
+		ExternalID:  id,
+		Title:       title,
+		URL:         jsonString(elem, f.URL),
+		Description: truncate(description, 500),
+		Author:      jsonString(elem, f.Author),
+		Score:       jsonInt(elem, f.Score),
+		Comments:    jsonInt(elem, f.Comments),
+		Tags:        tags,
+		PublishedAt: published,
+		CollectedAt: time.Now().UTC(),
+		Extra: map[string]any{
+			"source_name": j.spec.Name,
+		},
+	}, true
+}
+
+func paramOr(param, def string) string {
+	if param == "" {
+		return def
+	}
+	return param
+}
+
+// jsonPath walks a decoded JSON value along a dot/index path such as
+// "data.items[0].title". An empty path or "$" returns v itself.
+func jsonPath(v any, path string) (any, bool) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if path == "" {
+		return v, true
+	}
+
+	for _, part := range strings.Split(path, ".") {
+		key := part
+		var indices []int
+		if i := strings.IndexByte(part, '['); i >= 0 {
+			key = part[:i]
+			for _, idx := range strings.Split(part[i+1:], "[") {
+				n, err := strconv.Atoi(strings.TrimSuffix(idx, "]"))
+				if err != nil {
+					return nil, false
+				}
+				indices = append(indices, n)
+			}
+		}
+
+		if key != "" {
+			obj, ok := v.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if v, ok = obj[key]; !ok {
+				return nil, false
+			}
+		}
+
+		for _, n := range indices {
+			arr, ok := v.([]any)
+			if !ok || n < 0 || n >= len(arr) {
+				return nil, false
+			}
+			v = arr[n]
+		}
+	}
+	return v, true
+}
+
+func jsonField(v any, path string) any {
+	if path == "" {
+		return nil
+	}
+	f, _ := jsonPath(v, path)
+	return f
+}
+
+func jsonString(v any, path string) string {
+	return jsonScalar(jsonField(v, path))
+}
+
+func jsonScalar(v any) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case json.Number:
+		return x.String()
+	case bool:
+		return strconv.FormatBool(x)
+	}
+	return ""
+}
+
+func jsonInt(v any, path string) int {
+	switch x := jsonField(v, path).(type) {
+	case json.Number:
+		if n, err := x.Int64(); err == nil {
+			return int(n)
+		}
+		if f, err := x.Float64(); err == nil {
+			return int(f)
+		}
+	case string:
+		n, _ := strconv.Atoi(x)
+		return n
+	case []any:
+		// Allow mapping comments onto an array of comment objects.
+		return len(x)
+	}
+	return 0
+}
+
+// parseTimeValue accepts RFC 3339 strings, common date layouts and Unix
+// timestamps in seconds or milliseconds.
+func parseTimeValue(v any) (time.Time, bool) {
+	switch x := v.(type) {
+	case json.Number:
+		n, err := x.Int64()
+		if err != nil {
+			f, ferr := x.Float64()
+			if ferr != nil {
+				return time.Time{}, false
+			}
+			n = int64(f)
+		}
+		if n > 1e12 {
+			return time.UnixMilli(n).UTC(), true
+		}
+		return time.Unix(n, 0).UTC(), true
+	case string:
+		for _, layout := range []string{
+			time.RFC3339Nano,
+			"2006-01-02T15:04:05",
+			"2006-01-02 15:04:05",
+			time.RFC1123Z,
+			time.RFC1123,
+			"2006-01-02",
+		} {
+			if t, err := time.Parse(layout, x); err == nil {
+				return t.UTC(), true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// JSONAPIConfig declares a generic JSON API source without Go code.
+type JSONAPIConfig struct {
+	Name       string            `yaml:"name"`
+	Type       string            `yaml:"type"` // source type stored on items (default: name)
+	Enabled    bool              `yaml:"enabled"`
+	URL        string            `yaml:"url"`
+	Headers    map[string]string `yaml:"headers"`    // values may use ${ENV_VAR}
+	ItemsPath  string            `yaml:"items_path"` // path to the item array, e.g. "data.items"
+	Filter     bool              `yaml:"filter"`     // apply AI keyword filter
+	Pagination JSONAPIPagination `yaml:"pagination"`
+	Fields     JSONAPIFields     `yaml:"fields"`
+}
+
+// SourceJSONAPI is the registry type of the json_api config section. Its
+// sources carry the type declared by each entry.
+const SourceJSONAPI SourceType = "json_api"
+
+func init() {
+	Register(Factory[[]JSONAPIConfig]{
+		Type: SourceJSONAPI,
+		Enabled: func(apis []JSONAPIConfig) bool {
+			for _, api := range apis {
+				if api.Enabled {
+					return true
+				}
+			}
+			return false
+		},
+		New: func(apis []JSONAPIConfig, deps Deps) ([]Source, error) {
+			var (
+				sources []Source
+				errs    []error
+			)
+			for _, api := range apis {
+				if !api.Enabled {
+					continue
+				}
+				typ, err := customType(api.Name, api.Type)
+				if err == nil && api.URL == "" {
+					err = fmt.Errorf("%s: missing url", api.Name)
+				}
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				sources = append(sources, NewJSONAPI(JSONAPISpec{
+					Name:       api.Name,
+					Type:       typ,
+					URL:        api.URL,
+					Headers:    api.Headers,
+					ItemsPath:  api.ItemsPath,
+					Filter:     api.Filter,
+					Pagination: api.Pagination,
+					Fields:     api.Fields,
+				}, deps.Filter))
+			}
+			return sources, errors.Join(errs...)
+		},
+	})
+}
@@ -0,0 +1,335 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// buildFromYAML decodes a sources: mapping and builds it.
+func buildFromYAML(t *testing.T, doc string) ([]Source, error) {
+	t.Helper()
+	var cfg Config
+	if err := yaml.Unmarshal([]byte(doc), &cfg); err != nil {
+		t.Fatalf("decode config: %v", err)
+	}
+	return Build(cfg, Deps{})
+}
+
+func TestJSONAPIValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr string // "" = builds
+	}{
+		{
+			name: "valid json_api",
+			doc:  "json_api:\n  - {name: lobste, enabled: true, url: 'https://example.com/api'}",
+		},
+		{
+			name:    "json_api without name",
+			doc:     "json_api:\n  - {enabled: true, url: 'https://example.com/api'}",
+			wantErr: "missing name",
+		},
+		{
+			name:    "json_api without url",
+			doc:     "json_api:\n  - {name: foo, enabled: true}",
+			wantErr: "foo: missing url",
+		},
+		{
+			name:    "json_api with a built-in type",
+			doc:     "json_api:\n  - {name: foo, type: reddit, enabled: true, url: 'https://example.com'}",
+			wantErr: `type "reddit" is taken by the reddit source`,
+		},
+		{
+			name:    "json_api named after an alias",
+			doc:     "json_api:\n  - {name: hn, enabled: true, url: 'https://example.com'}",
+			wantErr: `type "hn" is taken by the hackernews source`,
+		},
+		{
+			name: "disabled entries are not validated",
+			doc:  "json_api:\n  - {enabled: false}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildFromYAML(t, tt.doc)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Build: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Build error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJSONAPIValidEntriesStillBuild(t *testing.T) {
+	sources, err := buildFromYAML(t, `
+json_api:
+  - {name: good, enabled: true, url: 'https://example.com/api'}
+  - {name: bad, enabled: true}
+`)
+	if err == nil {
+		t.Fatal("Build succeeded with an invalid entry")
+	}
+	if got := Select(sources, []string{"good"}); len(got) != 1 || got[0].Name() != "good" {
+		t.Fatalf("valid entry not built: %v", got)
+	}
+}
+
+func TestJSONPath(t *testing.T) {
+	var doc any
+	dec := json.NewDecoder(strings.NewReader(`{
+		"data": {"items": [{"title": "first", "links": [{"href": "a"}, {"href": "b"}]}]},
+		"grid": [[1, 2], [3, 4]],
+		"count": 2
+	}`))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path   string
+		want   string
+		wantOK bool
+	}{
+		{"data.items[0].title", "first", true},
+		{"$.data.items[0].title", "first", true},
+		{"data.items[0].links[1].href", "b", true},
+		{"grid[1][0]", "3", true},
+		{"count", "2", true},
+		{"data.items[1].title", "", false},
+		{"data.items[-1].title", "", false},
+		{"data.missing", "", false},
+		{"count.value", "", false},
+		{"data.items[x]", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			v, ok := jsonPath(doc, tt.path)
+			if ok != tt.wantOK || jsonScalar(v) != tt.want {
+				t.Fatalf("jsonPath = %v, %v, want %q, %v", v, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	if v, ok := jsonPath(doc, ""); !ok || v == nil {
+		t.Fatal("empty path doesn't return the root")
+	}
+}
+
+func TestJSONAPIMapsFields(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"result": {"stories": [
+			{"sid": 42, "head": {"text": "New open-weights LLM"}, "link": "https://example.com/llm",
+			 "summary": "A model.", "by": {"name": "ada"}, "stats": {"points": 120, "replies": [{}, {}, {}]},
+			 "at": 1767225600, "labels": ["llm", "release"]},
+			{"sid": "s-7", "head": {"text": "Gardening tips"}, "at": "2026-01-02T03:04:05Z", "labels": "garden, tips",
+			 "stats": {"points": "15", "replies": 4}},
+			{"sid": 8},
+			{"head": {"text": "no id"}}
+		]}}`)
+	}))
+	defer srv.Close()
+	t.Setenv("JSONAPI_TEST_TOKEN", "s3cret")
+
+	api := NewJSONAPI(JSONAPISpec{
+		Name:      "stories",
+		URL:       srv.URL,
+		Headers:   map[string]string{"Authorization": "Bearer ${JSONAPI_TEST_TOKEN}"},
+		ItemsPath: "result.stories",
+		Fields: JSONAPIFields{
+			ID: "sid", Title: "head.text", URL: "link", Description: "summary", Author: "by.name",
+			Score: "stats.points", Comments: "stats.replies", PublishedAt: "at", Tags: "labels",
+		},
+	}, nil)
+
+	items, err := api.Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("mapped %d items, want 2 (elements without id or title are skipped)", len(items))
+	}
+
+	got := items[0]
+	if got.ID != "stories:42" || got.Source != "stories" || got.ExternalID != "42" ||
+		got.Title != "New open-weights LLM" || got.URL != "https://example.com/llm" ||
+		got.Description != "A model." || got.Author != "ada" || got.Score != 120 || got.Comments != 3 {
+		t.Errorf("item = %+v", got)
+	}
+	if want := time.Unix(1767225600, 0).UTC(); !got.PublishedAt.Equal(want) {
+		t.Errorf("published = %s, want %s", got.PublishedAt, want)
+	}
+	if !slices.Equal(got.Tags, []string{"llm", "release"}) || got.Extra["source_name"] != "stories" {
+		t.Errorf("tags = %v, extra = %v", got.Tags, got.Extra)
+	}
+
+	got = items[1]
+	if got.ExternalID != "s-7" || got.Score != 15 || got.Comments != 4 || !slices.Equal(got.Tags, []string{"garden", "tips"}) {
+		t.Errorf("item = %+v", got)
+	}
+	if want := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC); !got.PublishedAt.Equal(want) {
+		t.Errorf("published = %s, want %s", got.PublishedAt, want)
+	}
+
+	// The AI filter drops the gardening story.
+	api.spec.Filter = true
+	api.filter = NewFilter(nil, nil)
+	if items, _ := api.Collect(context.Background()); len(items) != 1 || items[0].ExternalID != "42" {
+		t.Errorf("filtered Collect returned %d items", len(items))
+	}
+}
+
+// pagedAPI serves 7 items, 2 per request, paged the way the query asks, and
+// records the queries.
+type pagedAPI struct {
+	queries []string
+}
+
+func (p *pagedAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	p.queries = append(p.queries, r.URL.RawQuery)
+
+	first := 0
+	switch {
+	case q.Has("page"):
+		n, _ := strconv.Atoi(q.Get("page"))
+		first = n * 2
+	case q.Has("p"):
+		n, _ := strconv.Atoi(q.Get("p"))
+		first = (n - 1) * 2
+	case q.Has("offset"):
+		first, _ = strconv.Atoi(q.Get("offset"))
+	case q.Has("after"):
+		first, _ = strconv.Atoi(q.Get("after"))
+	}
+
+	items := []map[string]any{}
+	for i := first; i < min(first+2, 7); i++ {
+		items = append(items, map[string]any{"id": i, "title": fmt.Sprintf("item %d", i)})
+	}
+	resp := map[string]any{"items": items}
+	if first+2 < 7 {
+		resp["next"] = map[string]any{"cursor": strconv.Itoa(first + 2), "url": "?after=" + strconv.Itoa(first+2) + "&v=2"}
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func TestJSONAPIPagination(t *testing.T) {
+	zero, one, three := 0, 1, 3
+	tests := []struct {
+		name    string
+		pg      JSONAPIPagination
+		queries []string
+		ids     string
+	}{
+		{
+			name:    "single page",
+			pg:      JSONAPIPagination{},
+			queries: []string{""},
+			ids:     "0,1",
+		},
+		{
+			name:    "page numbers from the API default",
+			pg:      JSONAPIPagination{Type: "page", Param: "p", MaxPages: 3},
+			queries: []string{"", "p=2", "p=3"},
+			ids:     "0,1,2,3,4,5",
+		},
+		{
+			name:    "page numbers from one",
+			pg:      JSONAPIPagination{Type: "page", Param: "p", Start: &one, MaxPages: 2},
+			queries: []string{"p=1", "p=2"},
+			ids:     "0,1,2,3",
+		},
+		{
+			name:    "zero-based pages",
+			pg:      JSONAPIPagination{Type: "page", Start: &zero, MaxPages: 10},
+			queries: []string{"page=0", "page=1", "page=2", "page=3", "page=4"},
+			ids:     "0,1,2,3,4,5,6",
+		},
+		{
+			name:    "offset",
+			pg:      JSONAPIPagination{Type: "offset", MaxPages: 3},
+			queries: []string{"", "offset=2", "offset=4"},
+			ids:     "0,1,2,3,4,5",
+		},
+		{
+			name:    "offset from start",
+			pg:      JSONAPIPagination{Type: "offset", Start: &three, MaxPages: 2},
+			queries: []string{"offset=3", "offset=5"},
+			ids:     "3,4,5,6",
+		},
+		{
+			name:    "cursor",
+			pg:      JSONAPIPagination{Type: "cursor", Param: "after", Path: "next.cursor", MaxPages: 10},
+			queries: []string{"", "after=2", "after=4", "after=6"},
+			ids:     "0,1,2,3,4,5,6",
+		},
+		{
+			name:    "next url",
+			pg:      JSONAPIPagination{Type: "next_url", Path: "next.url", MaxPages: 2},
+			queries: []string{"", "after=2&v=2"},
+			ids:     "0,1,2,3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &pagedAPI{}
+			srv := httptest.NewServer(api)
+			defer srv.Close()
+
+			items, err := NewJSONAPI(JSONAPISpec{
+				Name: "paged", URL: srv.URL, ItemsPath: "items", Pagination: tt.pg,
+			}, nil).Collect(context.Background())
+			if err != nil {
+				t.Fatalf("Collect: %v", err)
+			}
+
+			var ids []string
+			for _, item := range items {
+				ids = append(ids, item.ExternalID)
+			}
+			if got := strings.Join(ids, ","); got != tt.ids {
+				t.Errorf("items = %s, want %s", got, tt.ids)
+			}
+			if !slices.Equal(api.queries, tt.queries) {
+				t.Errorf("queries = %q, want %q", api.queries, tt.queries)
+			}
+		})
+	}
+}
+
+func TestJSONAPIBadItemsPath(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items": {"not": "an array"}}`)
+	}))
+	defer srv.Close()
+
+	for path, want := range map[string]string{"items": "is not an array", "data": "not found"} {
+		_, err := NewJSONAPI(JSONAPISpec{Name: "bad", URL: srv.URL, ItemsPath: path}, nil).Collect(context.Background())
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("items path %q: Collect = %v, want %q", path, err, want)
+		}
+	}
+}
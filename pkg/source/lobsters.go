@@ -0,0 +1,209 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const lobstersBaseURL = "https://lobste.rs"
+
+// Lobsters collects AI-tagged stories from Lobste.rs.
+type Lobsters struct {
+	client   *http.Client
+	listings []string
+	tags     []string
+}
+
+// NewLobsters creates a new Lobste.rs collector.
+// listings are "hottest" and/or "newest"; only stories carrying one of tags
+// are kept. The tag page itself is always polled so quieter stories that
+// never reach the front page are still seen.
+func NewLobsters(listings, tags []string) *Lobsters {
+	if len(listings) == 0 {
+		listings = []string{"hottest", "newest"}
+	}
+	if len(tags) == 0 {
+		tags = []string{"ai", "ml"}
+	}
+	return &Lobsters{
+		client:   newHTTPClient(30 * time.Second),
+		listings: listings,
+		tags:     tags,
+	}
+}
+
+func (l *Lobsters) Name() SourceType { return SourceLobsters }
+
+func (l *Lobsters) Collect(ctx context.Context) ([]Item, error) {
+	wanted := make(map[string]bool, len(l.tags))
+	for _, t := range l.tags {
+		wanted[strings.ToLower(t)] = true
+	}
+
+	pages := make([]string, 0, len(l.listings)+1)
+	for _, listing := range l.listings {
+		pages = append(pages, "/"+url.PathEscape(listing)+".json")
+	}
+	pages = append(pages, "/t/"+strings.Join(l.tags, ",")+".json")
+
+	var (
+		items   []Item
+		seen    = make(map[string]bool)
+		lastErr error
+		ok      int
+	)
+
+	for _, page := range pages {
+		stories, err := l.fetch(ctx, page)
+		if err != nil {
+			fmt.Printf("  lobsters %s error: %v\n", page, err)
+			lastErr = err
+			continue
+		}
+		ok++
+
+		for _, s := range stories {
+			if seen[s.ShortID] || !s.hasTag(wanted) {
+				continue
+			}
+			seen[s.ShortID] = true
+			items = append(items, s.toItem())
+		}
+	}
+
+	if ok == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return items, nil
+}
+
+func (l *Lobsters) fetch(ctx context.Context, path string) ([]lobstersStory, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, lobstersBaseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create lobsters request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "airadar/1.0")
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch lobsters: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return nil, fmt.Errorf("lobsters: %w", err)
+	}
+
+	var stories []lobstersStory
+	if err := json.NewDecoder(resp.Body).Decode(&stories); err != nil {
+		return nil, fmt.Errorf("decode lobsters: %w", err)
+	}
+	return stories, nil
+}
+
+type lobstersStory struct {
+	ShortID          string          `json:"short_id"`
+	ShortIDURL       string          `json:"short_id_url"`
+	CommentsURL      string          `json:"comments_url"`
+	Title            string          `json:"title"`
+	URL              string          `json:"url"`
+	Score            int             `json:"score"`
+	CommentCount     int             `json:"comment_count"`
+	DescriptionPlain string          `json:"description_plain"`
+	Description      string          `json:"description"`
+	CreatedAt        time.Time       `json:"created_at"`
+	Tags             []string        `json:"tags"`
+	Submitter        json.RawMessage `json:"submitter_user"`
+}
+
+func (s lobstersStory) hasTag(wanted map[string]bool) bool {
+	for _, t := range s.Tags {
+		if wanted[strings.ToLower(t)] {
+			return true
+		}
+	}
+	return false
+}
+
+// submitter handles both the current string form of submitter_user and the
+// older {"username": ...} object.
+func (s lobstersStory) submitter() string {
+	var name string
+	if json.Unmarshal(s.Submitter, &name) == nil {
+		return name
+	}
+	var user struct {
+		Username string `json:"username"`
+	}
+	json.Unmarshal(s.Submitter, &user)
+	return user.Username
+}
+
+func (s lobstersStory) toItem() Item {
+	discussion := s.CommentsURL
+	if discussion == "" {
+		discussion = s.ShortIDURL
+	}
+	link := s.URL
+	if link == "" {
+		link = discussion // text post
+	}
+
+	description := s.DescriptionPlain
+	if description == "" {
+		description = stripHTML(s.Description)
+	}
+
+	published := s.CreatedAt
+	if published.IsZero() {
+		published = time.Now()
+	}
+
+	return Item{
+		ID:          fmt.Sprintf("lobsters:%s", s.ShortID),
+		Source:      SourceLobsters,
+		ExternalID:  s.ShortID,
+		Title:       s.Title,
+		URL:         link,
+		Description: truncate(description, 500),
+		Author:      s.submitter(),
+		Score:       s.Score,
+		Comments:    s.CommentCount,
+		Tags:        s.Tags,
+		PublishedAt: published.UTC(),
+		CollectedAt: time.Now().UTC(),
+		Extra: map[string]any{
+			"comments_url": discussion,
+		},
+	}
+}
+
+// LobstersConfig for Lobste.rs collector.
+type LobstersConfig struct {
+	Enabled  bool     `yaml:"enabled"`
+	Listings []string `yaml:"listings"` // hottest, newest
+	Tags     []string `yaml:"tags"`     // stories must carry one of these
+}
+
+func init() {
+	Register(Factory[LobstersConfig]{
+		Type: SourceLobsters,
+		Defaults: func() LobstersConfig {
+			return LobstersConfig{
+				Enabled:  true,
+				Listings: []string{"hottest", "newest"},
+				Tags:     []string{"ai", "ml"},
+			}
+		},
+		Enabled: func(c LobstersConfig) bool { return c.Enabled },
+		New: func(c LobstersConfig, deps Deps) ([]Source, error) {
+			return []Source{NewLobsters(c.Listings, c.Tags)}, nil
+		},
+	})
+}
@@ -0,0 +1,413 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MastodonInstance is a Mastodon server and the timelines to read from it.
+type MastodonInstance struct {
+	URL      string   `yaml:"url"`
+	Token    string   `yaml:"token"`    // optional, some instances require auth for timelines
+	Hashtags []string `yaml:"hashtags"` // without the leading '#'
+	Accounts []string `yaml:"accounts"` // usernames local to the instance, or user@host
+}
+
+// Mastodon collects AI posts from Mastodon hashtag and account timelines.
+type Mastodon struct {
+	client    *http.Client
+	instances []MastodonInstance
+	filter    *Filter
+	maxPages  int
+
+	mu         sync.Mutex
+	cursors    *Cursors          // timeline -> newest status ID read without gaps
+	accountIDs map[string]string // instance + acct -> account ID
+}
+
+// NewMastodon creates a new Mastodon collector.
+func NewMastodon(instances []MastodonInstance, filter *Filter) *Mastodon {
+	for i := range instances {
+		instances[i].URL = strings.TrimRight(instances[i].URL, "/")
+	}
+	return &Mastodon{
+		client:     newHTTPClient(30 * time.Second),
+		instances:  instances,
+		filter:     filter,
+		maxPages:   5,
+		cursors:    newCursors(nil, ""),
+		accountIDs: make(map[string]string),
+	}
+}
+
+func (m *Mastodon) Name() SourceType { return SourceMastodon }
+
+func (m *Mastodon) setCursors(c *Cursors) { m.cursors = c }
+
+func (m *Mastodon) Collect(ctx context.Context) ([]Item, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var allItems []Item
+	seen := make(map[string]bool)
+
+	add := func(items []Item) {
+		for _, item := range items {
+			if !seen[item.ID] {
+				seen[item.ID] = true
+				allItems = append(allItems, item)
+			}
+		}
+	}
+
+	for _, inst := range m.instances {
+		for _, tag := range inst.Hashtags {
+			tag = strings.TrimPrefix(tag, "#")
+			path := "/api/v1/timelines/tag/" + url.PathEscape(tag)
+
+			// Hashtag timelines are noisy, so run them through the AI filter.
+			items, err := m.collectTimeline(ctx, inst, path, nil, true)
+			if err != nil {
+				fmt.Printf("  mastodon %s #%s error: %v\n", inst.URL, tag, err)
+				continue
+			}
+			add(items)
+		}
+
+		for _, acct := range inst.Accounts {
+			id, err := m.lookupAccount(ctx, inst, acct)
+			if err != nil {
+				fmt.Printf("  mastodon %s @%s error: %v\n", inst.URL, acct, err)
+				continue
+			}
+
+			path := "/api/v1/accounts/" + id + "/statuses"
+			params := url.Values{}
+			params.Set("exclude_replies", "true")
+			params.Set("exclude_reblogs", "true")
+
+			items, err := m.collectTimeline(ctx, inst, path, params, false)
+			if err != nil {
+				fmt.Printf("  mastodon %s @%s error: %v\n", inst.URL, acct, err)
+				continue
+			}
+			add(items)
+		}
+	}
+
+	return allItems, nil
+}
+
+// mastoPageSize is the most statuses Mastodon returns per request.
+const mastoPageSize = 40
+
+// collectTimeline reads a timeline in two passes. The first pages from the
+// newest status down to the 24h cutoff (or the rewind time) and re-emits
+// those statuses on every run, so their favourites and boosts are
+// snapshotted like Bluesky posts. If it doesn't get down to the since_id
+// cursor, the newest status seen by earlier runs, the second pass pages
+// forward from the cursor with min_id, and the cursor only moves as far as
+// that pass read: a busy hashtag is caught up over several runs instead of
+// losing the statuses past the page limit.
+func (m *Mastodon) collectTimeline(ctx context.Context, inst MastodonInstance, path string, params url.Values, filter bool) ([]Item, error) {
+	key := "since_id:" + inst.URL + path
+	sinceID, err := m.cursors.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	cutoff := time.Now().Add(-24 * time.Hour)
+	if since := m.cursors.Since(); !since.IsZero() {
+		cutoff = since
+	}
+
+	var (
+		recent   []mastoStatus // newest first
+		caughtUp = sinceID == ""
+		maxID    string
+	)
+	for page := 0; page < m.maxPages; page++ {
+		statuses, err := m.page(ctx, inst, path, params, "max_id", maxID)
+		if err != nil {
+			return nil, err
+		}
+
+		reachedCutoff := false
+		for _, st := range statuses {
+			if !caughtUp && !mastoIDAfter(st.ID, sinceID) {
+				caughtUp = true
+			}
+			if st.CreatedAt.Before(cutoff) {
+				reachedCutoff = true
+				break
+			}
+			recent = append(recent, st)
+		}
+		if len(statuses) < mastoPageSize {
+			caughtUp = true
+			break
+		}
+		if reachedCutoff {
+			break
+		}
+		maxID = statuses[len(statuses)-1].ID
+	}
+
+	// Catch up from the cursor to the oldest status read above.
+	var missed []mastoStatus
+	cursor := sinceID
+	if !caughtUp {
+		oldest := ""
+		if len(recent) > 0 {
+			oldest = recent[len(recent)-1].ID
+		}
+		for page := 0; page < m.maxPages && !caughtUp; page++ {
+			statuses, err := m.page(ctx, inst, path, params, "min_id", cursor)
+			if err != nil {
+				return nil, err
+			}
+			if len(statuses) == 0 {
+				caughtUp = true
+				break
+			}
+			for _, st := range statuses {
+				if oldest != "" && !mastoIDAfter(oldest, st.ID) {
+					caughtUp = true // read by the first pass
+					continue
+				}
+				missed = append(missed, st)
+			}
+			cursor = statuses[0].ID
+			if len(statuses) < mastoPageSize {
+				caughtUp = true
+			}
+		}
+	}
+	if caughtUp && len(recent) > 0 {
+		cursor = recent[0].ID
+	}
+
+	if cursor != "" && cursor != sinceID {
+		if err := m.cursors.Set(ctx, key, cursor); err != nil {
+			fmt.Printf("  mastodon %s cursor error: %v\n", inst.URL, err)
+		}
+	}
+
+	var items []Item
+	for _, st := range append(recent, missed...) {
+		if item, ok := m.statusItem(inst, st, filter); ok {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// page fetches one page of a timeline, with bound ("max_id" or "min_id")
+// set to id unless it is empty.
+func (m *Mastodon) page(ctx context.Context, inst MastodonInstance, path string, params url.Values, bound, id string) ([]mastoStatus, error) {
+	q := url.Values{}
+	for k, v := range params {
+		q[k] = v
+	}
+	q.Set("limit", strconv.Itoa(mastoPageSize))
+	if id != "" {
+		q.Set(bound, id)
+	}
+
+	var statuses []mastoStatus
+	if err := m.get(ctx, inst, path, q, &statuses); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// mastoIDAfter reports whether status ID a is newer than b. IDs are
+// numeric strings (or sortable flake IDs on other servers) that grow with
+// time.
+func mastoIDAfter(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) > len(b)
+	}
+	return a > b
+}
+
+// statusItem converts a status into an item. Boosts, statuses without text
+// and, if filter is set, statuses not about AI are skipped.
+func (m *Mastodon) statusItem(inst MastodonInstance, st mastoStatus, filter bool) (Item, bool) {
+	if st.Reblog != nil {
+		return Item{}, false
+	}
+
+	text := stripHTML(st.Content)
+	if st.SpoilerText != "" {
+		text = st.SpoilerText + "\n" + text
+	}
+
+	var tags []string
+	for _, t := range st.Tags {
+		tags = append(tags, t.Name)
+	}
+
+	if filter && m.filter != nil && !m.filter.MatchesAI(text+" "+strings.Join(tags, " ")) {
+		return Item{}, false
+	}
+
+	title := text
+	extra := map[string]any{
+		"instance":   inst.URL,
+		"account":    st.Account.Acct,
+		"favourites": st.FavouritesCount,
+		"boosts":     st.ReblogsCount,
+	}
+	if st.Card != nil && st.Card.URL != "" {
+		extra["link"] = st.Card.URL
+		if st.Card.Title != "" {
+			title = st.Card.Title
+		}
+	}
+	if strings.TrimSpace(title) == "" {
+		return Item{}, false
+	}
+
+	link := st.URL
+	if link == "" {
+		link = st.URI
+	}
+
+	return Item{
+		ID:          fmt.Sprintf("mastodon:%s", st.URI),
+		Source:      SourceMastodon,
+		ExternalID:  st.URI,
+		Title:       truncate(title, 280),
+		URL:         link,
+		Description: truncate(text, 500),
+		Author:      st.Account.Acct,
+		Score:       st.FavouritesCount + st.ReblogsCount,
+		Comments:    st.RepliesCount,
+		Tags:        tags,
+		PublishedAt: st.CreatedAt.UTC(),
+		CollectedAt: time.Now().UTC(),
+		Extra:       extra,
+	}, true
+}
+
+func (m *Mastodon) lookupAccount(ctx context.Context, inst MastodonInstance, acct string) (string, error) {
+	acct = strings.TrimPrefix(acct, "@")
+	key := inst.URL + "/" + acct
+	if id, ok := m.accountIDs[key]; ok {
+		return id, nil
+	}
+
+	var account struct {
+		ID string `json:"id"`
+	}
+	if err := m.get(ctx, inst, "/api/v1/accounts/lookup", url.Values{"acct": {acct}}, &account); err != nil {
+		return "", err
+	}
+	if account.ID == "" {
+		return "", fmt.Errorf("account not found")
+	}
+
+	m.accountIDs[key] = account.ID
+	return account.ID, nil
+}
+
+func (m *Mastodon) get(ctx context.Context, inst MastodonInstance, path string, params url.Values, out any) error {
+	reqURL := inst.URL + path + "?" + params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("create mastodon request: %w", err)
+	}
+	req.Header.Set("User-Agent", "airadar/1.0")
+	if inst.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+inst.Token)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch mastodon %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return fmt.Errorf("mastodon %s: %w", path, err)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode mastodon %s: %w", path, err)
+	}
+	return nil
+}
+
+var (
+	htmlBreakRe = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</li>|</h[1-6]>`)
+	htmlTagRe   = regexp.MustCompile(`<[^>]*>`)
+	spaceRe     = regexp.MustCompile(`[ \t]+`)
+)
+
+// stripHTML converts an HTML fragment into plain text, keeping paragraph
+// breaks as newlines.
+func stripHTML(s string) string {
+	s = htmlBreakRe.ReplaceAllString(s, "\n")
+	s = htmlTagRe.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	s = spaceRe.ReplaceAllString(s, " ")
+
+	lines := strings.Split(s, "\n")
+	out := lines[:0]
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" {
+			out = append(out, line)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+type mastoStatus struct {
+	ID              string    `json:"id"`
+	URI             string    `json:"uri"`
+	URL             string    `json:"url"`
+	CreatedAt       time.Time `json:"created_at"`
+	Content         string    `json:"content"`
+	SpoilerText     string    `json:"spoiler_text"`
+	RepliesCount    int       `json:"replies_count"`
+	ReblogsCount    int       `json:"reblogs_count"`
+	FavouritesCount int       `json:"favourites_count"`
+	Reblog          *struct{} `json:"reblog"`
+	Account         struct {
+		Acct        string `json:"acct"`
+		DisplayName string `json:"display_name"`
+	} `json:"account"`
+	Tags []struct {
+		Name string `json:"name"`
+	} `json:"tags"`
+	Card *struct {
+		URL         string `json:"url"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	} `json:"card"`
+}
+
+// MastodonConfig for Mastodon collector.
+type MastodonConfig struct {
+	Enabled   bool               `yaml:"enabled"`
+	Instances []MastodonInstance `yaml:"instances"`
+}
+
+func init() {
+	Register(Factory[MastodonConfig]{
+		Type:    SourceMastodon,
+		Enabled: func(c MastodonConfig) bool { return c.Enabled },
+		New: func(c MastodonConfig, deps Deps) ([]Source, error) {
+			return []Source{NewMastodon(c.Instances, deps.Filter)}, nil
+		},
+	})
+}
@@ -0,0 +1,139 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeMastodon serves a hashtag timeline like Mastodon: newest first,
+// bounded by max_id, since_id or min_id.
+type fakeMastodon struct {
+	mu       sync.Mutex
+	statuses []mastoStatus // oldest first
+}
+
+func (f *fakeMastodon) post(n int, at time.Time, favourites int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := 0; i < n; i++ {
+		id := strconv.Itoa(100000 + len(f.statuses))
+		st := mastoStatus{
+			ID: id, URI: "https://example.social/statuses/" + id, CreatedAt: at,
+			Content: "<p>New LLM release " + id + "</p>", FavouritesCount: favourites,
+		}
+		st.Account.Acct = "someone"
+		f.statuses = append(f.statuses, st)
+	}
+}
+
+func (f *fakeMastodon) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	var matching []mastoStatus
+	for _, st := range f.statuses {
+		if id := q.Get("max_id"); id != "" && !mastoIDAfter(id, st.ID) {
+			continue
+		}
+		if id := q.Get("min_id"); id != "" && !mastoIDAfter(st.ID, id) {
+			continue
+		}
+		if id := q.Get("since_id"); id != "" && !mastoIDAfter(st.ID, id) {
+			continue
+		}
+		matching = append(matching, st)
+	}
+
+	// min_id returns the statuses right after it, others the newest.
+	if q.Get("min_id") != "" {
+		matching = matching[:min(limit, len(matching))]
+	} else {
+		matching = matching[max(0, len(matching)-limit):]
+	}
+	page := make([]mastoStatus, 0, len(matching))
+	for i := len(matching) - 1; i >= 0; i-- {
+		page = append(page, matching[i])
+	}
+	json.NewEncoder(w).Encode(page)
+}
+
+func newTestMastodon(t *testing.T, f *fakeMastodon) *Mastodon {
+	t.Helper()
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	m := NewMastodon([]MastodonInstance{{URL: srv.URL, Hashtags: []string{"LLM"}}}, nil)
+	m.maxPages = 2
+	return m
+}
+
+func collectIDs(t *testing.T, m *Mastodon, seen map[string]int) []Item {
+	t.Helper()
+	ctx := context.Background()
+	items, err := m.Collect(ctx)
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	m.cursors.commit(ctx)
+	for _, item := range items {
+		seen[item.ExternalID]++
+	}
+	return items
+}
+
+func TestMastodonCatchesUpPastPageLimit(t *testing.T) {
+	f := &fakeMastodon{}
+	now := time.Now()
+	f.post(10, now.Add(-2*time.Hour), 0)
+	m := newTestMastodon(t, f)
+
+	seen := make(map[string]int)
+	collectIDs(t, m, seen)
+	if len(seen) != 10 {
+		t.Fatalf("first run read %d statuses, want 10", len(seen))
+	}
+
+	// 250 statuses since: more than the 2 pages of 40 each pass reads, and
+	// older than the refresh window, so only the cursor can find them.
+	f.post(250, now.Add(-30*time.Hour), 0)
+	f.post(50, now.Add(-time.Minute), 0)
+	for run := 0; run < 5; run++ {
+		collectIDs(t, m, seen)
+	}
+	if len(seen) != 310 {
+		t.Fatalf("read %d of 310 statuses", len(seen))
+	}
+}
+
+func TestMastodonRefreshesRecentCounts(t *testing.T) {
+	f := &fakeMastodon{}
+	f.post(1, time.Now().Add(-48*time.Hour), 0)
+	f.post(3, time.Now().Add(-time.Hour), 0)
+	m := newTestMastodon(t, f)
+
+	collectIDs(t, m, map[string]int{})
+
+	f.mu.Lock()
+	for i := range f.statuses {
+		f.statuses[i].FavouritesCount = 7
+		f.statuses[i].ReblogsCount = 2
+	}
+	f.mu.Unlock()
+
+	items := collectIDs(t, m, map[string]int{})
+	if len(items) != 3 {
+		t.Fatalf("second run returned %d statuses, want the 3 recent ones", len(items))
+	}
+	for _, item := range items {
+		if item.Score != 9 {
+			t.Fatalf("%s score = %d, want the current 9", item.ExternalID, item.Score)
+		}
+	}
+}
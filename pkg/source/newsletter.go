@@ -0,0 +1,502 @@
+package source
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/client"
+	"github.com/emersion/go-imap/commands"
+	"github.com/emersion/go-message"
+	_ "github.com/emersion/go-message/charset" // decode non-UTF-8 newsletters
+	"github.com/emersion/go-message/mail"
+)
+
+// NewsletterOptions configures the IMAP newsletter collector.
+type NewsletterOptions struct {
+	Addr     string // host:port of the IMAP server
+	Security string // "tls" (default), "starttls" or "none"
+	Username string
+	Password string
+	Mailbox  string   // folder to read (default: INBOX)
+	Senders  []string // only messages from these addresses or domains (default: all)
+	Days     int      // only messages received this recently
+	Limit    int      // messages per run, newest first
+
+	// ProcessedFlag is the keyword set on messages once their links were
+	// collected and stored; flagged messages are skipped on later runs.
+	ProcessedFlag string
+	// MoveTo, when set, moves processed messages to this folder.
+	MoveTo string
+	// Resolve follows tracking redirects so items carry the article URL.
+	Resolve bool
+}
+
+// Newsletter reads AI newsletters from an IMAP mailbox and turns every
+// linked story into its own item. The AI filter is applied per link, so a
+// newsletter that also covers other topics only contributes its AI stories.
+type Newsletter struct {
+	opts   NewsletterOptions
+	filter *Filter
+	client *http.Client // for resolving redirects
+
+	mu      sync.Mutex
+	pending *newsletterBatch // read by the last Collect, flagged on Commit
+}
+
+// newsletterBatch is the messages one Collect read. UIDs are only valid
+// within the mailbox's UIDVALIDITY.
+type newsletterBatch struct {
+	uidValidity uint32
+	uids        *imap.SeqSet
+}
+
+// NewNewsletter creates a new IMAP newsletter collector.
+func NewNewsletter(opts NewsletterOptions, filter *Filter) *Newsletter {
+	if opts.Security == "" {
+		opts.Security = "tls"
+	}
+	if opts.Mailbox == "" {
+		opts.Mailbox = "INBOX"
+	}
+	if opts.Days <= 0 {
+		opts.Days = 7
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = 50
+	}
+	if opts.ProcessedFlag == "" {
+		opts.ProcessedFlag = "$AiradarProcessed"
+	}
+	return &Newsletter{
+		opts:   opts,
+		filter: filter,
+		client: newHTTPClient(15 * time.Second),
+	}
+}
+
+func (n *Newsletter) Name() SourceType { return SourceNewsletter }
+
+// Collect reads unprocessed newsletters. They are flagged as processed (and
+// moved) by Commit, once their items are stored.
+func (n *Newsletter) Collect(ctx context.Context) ([]Item, error) {
+	if n.opts.Addr == "" {
+		return nil, fmt.Errorf("newsletter: no IMAP server configured")
+	}
+
+	n.mu.Lock()
+	n.pending = nil
+	n.mu.Unlock()
+
+	c, mbox, err := n.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Logout()
+
+	uids, err := c.UidSearch(n.criteria())
+	if err != nil {
+		return nil, fmt.Errorf("search %s: %w", n.opts.Mailbox, err)
+	}
+	if len(uids) == 0 {
+		return nil, nil
+	}
+	if len(uids) > n.opts.Limit {
+		uids = uids[len(uids)-n.opts.Limit:] // UIDs ascend with arrival
+	}
+
+	messages, err := n.fetch(c, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		items     []Item
+		processed = new(imap.SeqSet)
+		seen      = make(map[string]bool)
+	)
+	for _, msg := range messages {
+		links, err := parseNewsletter(msg.body)
+		if err != nil {
+			fmt.Printf("  newsletter %q error: %v\n", msg.subject, err)
+			continue
+		}
+		processed.AddNum(msg.uid)
+
+		for _, link := range links {
+			if n.filter != nil && !n.filter.MatchesAI(link.title+" "+link.context) {
+				continue
+			}
+			if n.opts.Resolve {
+				link.url = n.resolve(ctx, link.url)
+			}
+			if seen[link.url] {
+				continue
+			}
+			seen[link.url] = true
+			items = append(items, link.toItem(msg))
+		}
+	}
+
+	if !processed.Empty() {
+		n.mu.Lock()
+		n.pending = &newsletterBatch{uidValidity: mbox.UidValidity, uids: processed}
+		n.mu.Unlock()
+	}
+
+	return items, nil
+}
+
+// Commit flags the newsletters read by the last Collect as processed. If
+// the mailbox was recreated in between (UIDVALIDITY changed), they are left
+// alone and read again.
+func (n *Newsletter) Commit(ctx context.Context) error {
+	n.mu.Lock()
+	batch := n.pending
+	n.pending = nil
+	n.mu.Unlock()
+	if batch == nil {
+		return nil
+	}
+
+	c, mbox, err := n.open(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Logout()
+
+	if mbox.UidValidity != batch.uidValidity {
+		return fmt.Errorf("newsletter: %s changed UIDVALIDITY, messages will be read again", n.opts.Mailbox)
+	}
+	return n.markProcessed(c, batch.uids)
+}
+
+// open connects and selects the mailbox. The connection is dropped when ctx
+// is cancelled, as go-imap has no context support.
+func (n *Newsletter) open(ctx context.Context) (*client.Client, *imap.MailboxStatus, error) {
+	c, err := n.connect()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stop := context.AfterFunc(ctx, func() { c.Terminate() })
+	go func() {
+		<-c.LoggedOut()
+		stop()
+	}()
+
+	mbox, err := c.Select(n.opts.Mailbox, false)
+	if err != nil {
+		c.Logout()
+		return nil, nil, fmt.Errorf("select %s: %w", n.opts.Mailbox, err)
+	}
+	return c, mbox, nil
+}
+
+func (n *Newsletter) connect() (*client.Client, error) {
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	host, _, _ := net.SplitHostPort(n.opts.Addr)
+	tlsConfig := &tls.Config{ServerName: host}
+
+	var (
+		c   *client.Client
+		err error
+	)
+	switch n.opts.Security {
+	case "tls":
+		c, err = client.DialWithDialerTLS(dialer, n.opts.Addr, tlsConfig)
+	case "starttls", "none":
+		c, err = client.DialWithDialer(dialer, n.opts.Addr)
+	default:
+		return nil, fmt.Errorf("newsletter: unknown security %q", n.opts.Security)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("connect %s: %w", n.opts.Addr, err)
+	}
+	c.Timeout = 60 * time.Second
+
+	if n.opts.Security == "starttls" {
+		if err := c.StartTLS(tlsConfig); err != nil {
+			c.Logout()
+			return nil, fmt.Errorf("starttls: %w", err)
+		}
+	}
+	if err := c.Login(n.opts.Username, n.opts.Password); err != nil {
+		c.Logout()
+		return nil, fmt.Errorf("imap login: %w", err)
+	}
+	return c, nil
+}
+
+// criteria selects recent unprocessed messages from the configured senders.
+func (n *Newsletter) criteria() *imap.SearchCriteria {
+	criteria := imap.NewSearchCriteria()
+	criteria.Since = time.Now().AddDate(0, 0, -n.opts.Days)
+	criteria.WithoutFlags = []string{n.opts.ProcessedFlag}
+
+	if len(n.opts.Senders) > 0 {
+		from := fromAny(n.opts.Senders)
+		criteria.Header = from.Header
+		criteria.Or = from.Or
+	}
+	return criteria
+}
+
+// fromAny matches messages from any of senders: FROM a OR (FROM b OR ...).
+func fromAny(senders []string) *imap.SearchCriteria {
+	c := imap.NewSearchCriteria()
+	if len(senders) == 1 {
+		c.Header.Add("From", senders[0])
+		return c
+	}
+	c.Or = [][2]*imap.SearchCriteria{{fromAny(senders[:1]), fromAny(senders[1:])}}
+	return c
+}
+
+type newsletterMessage struct {
+	uid       uint32
+	subject   string
+	sender    string
+	messageID string
+	date      time.Time
+	body      []byte
+}
+
+func (n *Newsletter) fetch(c *client.Client, uids []uint32) ([]newsletterMessage, error) {
+	seqset := new(imap.SeqSet)
+	seqset.AddNum(uids...)
+
+	section := &imap.BodySectionName{Peek: true}
+	fetchItems := []imap.FetchItem{imap.FetchUid, imap.FetchEnvelope, imap.FetchInternalDate, section.FetchItem()}
+
+	ch := make(chan *imap.Message, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- c.UidFetch(seqset, fetchItems, ch)
+	}()
+
+	var messages []newsletterMessage
+	for m := range ch {
+		body := m.GetBody(section)
+		if body == nil {
+			continue
+		}
+		raw, err := io.ReadAll(body)
+		if err != nil {
+			continue
+		}
+
+		msg := newsletterMessage{uid: m.Uid, date: m.InternalDate, body: raw}
+		if env := m.Envelope; env != nil {
+			msg.subject = env.Subject
+			msg.messageID = env.MessageId
+			if !env.Date.IsZero() {
+				msg.date = env.Date
+			}
+			if len(env.From) > 0 {
+				msg.sender = env.From[0].PersonalName
+				if msg.sender == "" {
+					msg.sender = env.From[0].Address()
+				}
+			}
+		}
+		messages = append(messages, msg)
+	}
+	if err := <-done; err != nil {
+		return nil, fmt.Errorf("fetch messages: %w", err)
+	}
+	return messages, nil
+}
+
+func (n *Newsletter) markProcessed(c *client.Client, uids *imap.SeqSet) error {
+	flags := []any{n.opts.ProcessedFlag}
+	if err := c.UidStore(uids, imap.FormatFlagsOp(imap.AddFlags, true), flags, nil); err != nil {
+		return fmt.Errorf("flag messages: %w", err)
+	}
+	if n.opts.MoveTo == "" {
+		return nil
+	}
+	if err := c.UidMove(uids, n.opts.MoveTo); err == nil {
+		return nil
+	}
+	// Some servers advertise MOVE but refuse it; copy and delete instead.
+	if err := c.UidCopy(uids, n.opts.MoveTo); err != nil {
+		return fmt.Errorf("copy messages to %s: %w", n.opts.MoveTo, err)
+	}
+	deleted := []any{imap.DeletedFlag}
+	if err := c.UidStore(uids, imap.FormatFlagsOp(imap.AddFlags, true), deleted, nil); err != nil {
+		return fmt.Errorf("delete moved messages: %w", err)
+	}
+
+	// A plain EXPUNGE would also remove messages the user deleted. Without
+	// UIDPLUS the copies stay flagged for the mail client to expunge.
+	if ok, err := c.Support("UIDPLUS"); err != nil || !ok {
+		return nil
+	}
+	status, err := c.Execute(&commands.Uid{Cmd: uidExpunge{uids}}, nil)
+	if err == nil {
+		err = status.Err()
+	}
+	if err != nil {
+		return fmt.Errorf("expunge moved messages: %w", err)
+	}
+	return nil
+}
+
+// uidExpunge is the UIDPLUS EXPUNGE command (RFC 4315), sent as UID EXPUNGE:
+// it only removes the given messages.
+type uidExpunge struct {
+	uids *imap.SeqSet
+}
+
+func (cmd uidExpunge) Command() *imap.Command {
+	return &imap.Command{Name: "EXPUNGE", Arguments: []any{cmd.uids}}
+}
+
+// resolve follows redirects of click-tracking links. The original URL is
+// kept when the target can't be reached.
+func (n *Newsletter) resolve(ctx context.Context, link string) string {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, link, nil)
+	if err != nil {
+		return link
+	}
+	req.Header.Set("User-Agent", "airadar/1.0")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return link
+	}
+	resp.Body.Close()
+	return resp.Request.URL.String()
+}
+
+// readNewsletterBody returns the HTML and plain text parts of a message.
+func readNewsletterBody(raw []byte) (htmlBody, textBody string, err error) {
+	mr, err := mail.CreateReader(strings.NewReader(string(raw)))
+	if err != nil && !message.IsUnknownCharset(err) {
+		return "", "", fmt.Errorf("parse message: %w", err)
+	}
+
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil && !message.IsUnknownCharset(err) {
+			return "", "", fmt.Errorf("read message part: %w", err)
+		}
+
+		h, ok := part.Header.(*mail.InlineHeader)
+		if !ok {
+			continue // attachment
+		}
+		contentType, _, _ := h.ContentType()
+		b, err := io.ReadAll(part.Body)
+		if err != nil {
+			continue
+		}
+		switch contentType {
+		case "text/html":
+			if htmlBody == "" {
+				htmlBody = string(b)
+			}
+		case "text/plain", "":
+			if textBody == "" {
+				textBody = string(b)
+			}
+		}
+	}
+	return htmlBody, textBody, nil
+}
+
+func (l newsletterLink) toItem(msg newsletterMessage) Item {
+	sum := sha256.Sum256([]byte(l.url))
+	externalID := hex.EncodeToString(sum[:8])
+
+	published := msg.date
+	if published.IsZero() {
+		published = time.Now()
+	}
+
+	return Item{
+		ID:          fmt.Sprintf("newsletter:%s", externalID),
+		Source:      SourceNewsletter,
+		ExternalID:  externalID,
+		Title:       l.title,
+		URL:         l.url,
+		Description: truncate(l.context, 500),
+		Author:      msg.sender,
+		PublishedAt: published.UTC(),
+		CollectedAt: time.Now().UTC(),
+		Extra: map[string]any{
+			"newsletter": msg.sender,
+			"subject":    msg.subject,
+			"message_id": msg.messageID,
+		},
+	}
+}
+
+// NewsletterConfig for the IMAP newsletter collector.
+type NewsletterConfig struct {
+	Enabled       bool     `yaml:"enabled"`
+	Addr          string   `yaml:"addr"`     // host:port, e.g. imap.gmail.com:993
+	Security      string   `yaml:"security"` // tls, starttls or none
+	Username      string   `yaml:"username"`
+	Password      string   `yaml:"password"`
+	Mailbox       string   `yaml:"mailbox"`
+	Senders       []string `yaml:"senders"` // only mail from these addresses or domains
+	Days          int      `yaml:"days"`
+	Limit         int      `yaml:"limit"`          // messages per run
+	ProcessedFlag string   `yaml:"processed_flag"` // IMAP keyword marking read newsletters
+	MoveTo        string   `yaml:"move_to"`        // folder for processed newsletters
+	Resolve       bool     `yaml:"resolve"`        // follow click-tracking redirects
+}
+
+func init() {
+	Register(Factory[NewsletterConfig]{
+		Type:    SourceNewsletter,
+		Aliases: []string{"mail"},
+		Defaults: func() NewsletterConfig {
+			return NewsletterConfig{
+				Enabled:       false,
+				Security:      "tls",
+				Mailbox:       "INBOX",
+				Days:          7,
+				Limit:         50,
+				ProcessedFlag: "$AiradarProcessed",
+				Resolve:       true,
+			}
+		},
+		Env: func(c *NewsletterConfig) {
+			if v := os.Getenv("IMAP_PASSWORD"); v != "" {
+				c.Password = v
+			}
+		},
+		Enabled: func(c NewsletterConfig) bool { return c.Enabled },
+		New: func(c NewsletterConfig, deps Deps) ([]Source, error) {
+			return []Source{NewNewsletter(NewsletterOptions{
+				Addr:          c.Addr,
+				Security:      c.Security,
+				Username:      c.Username,
+				Password:      c.Password,
+				Mailbox:       c.Mailbox,
+				Senders:       c.Senders,
+				Days:          c.Days,
+				Limit:         c.Limit,
+				ProcessedFlag: c.ProcessedFlag,
+				MoveTo:        c.MoveTo,
+				Resolve:       c.Resolve,
+			}, deps.Filter)}, nil
+		},
+	})
+}
@@ -0,0 +1,215 @@
+package source
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// newsletterLink is one story linked from a newsletter.
+type newsletterLink struct {
+	title   string
+	url     string
+	context string // text of the paragraph or list entry around the link
+}
+
+// parseNewsletter extracts the story links of a raw email. The HTML part is
+// preferred; plain-text newsletters are scanned for bare URLs.
+func parseNewsletter(raw []byte) ([]newsletterLink, error) {
+	htmlBody, textBody, err := readNewsletterBody(raw)
+	if err != nil {
+		return nil, err
+	}
+	if htmlBody != "" {
+		return htmlLinks(htmlBody)
+	}
+	return textLinks(textBody), nil
+}
+
+// Anchor texts that say nothing about the story; the heading or paragraph
+// around them is used as the title instead.
+var genericLinkText = map[string]bool{
+	"here": true, "link": true, "read more": true, "read": true, "more": true,
+	"paper": true, "code": true, "blog": true, "post": true, "article": true,
+	"source": true, "demo": true, "github": true, "arxiv": true, "video": true,
+	"continue reading": true, "learn more": true, "full story": true,
+}
+
+// Newsletter chrome rather than stories.
+var skipLinkPattern = regexp.MustCompile(`(?i)unsubscribe|preferences|view (it )?(in|online)|in your browser|manage (your )?subscription|forward to a friend|update your profile|privacy policy|list-manage\.com/(profile|unsubscribe)|/(intent/tweet|sharer|shareArticle|share\?)`)
+
+func htmlLinks(body string) ([]newsletterLink, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	doc.Find("script, style, head").Remove()
+
+	var (
+		links []newsletterLink
+		seen  = make(map[string]bool)
+	)
+	doc.Find("a[href]").Each(func(_ int, a *goquery.Selection) {
+		href, _ := a.Attr("href")
+		href = strings.TrimSpace(href)
+		text := collapseSpace(a.Text())
+		if !isStoryURL(href) || seen[href] || text == "" || skipLinkPattern.MatchString(href+" "+text) {
+			return
+		}
+
+		context := linkContext(a)
+		title := text
+		if isGenericLinkText(text) {
+			title = headingBefore(a)
+			if title == "" {
+				title = firstSentence(context)
+			}
+		}
+		if title == "" {
+			return
+		}
+
+		seen[href] = true
+		links = append(links, newsletterLink{
+			title:   truncate(title, 200),
+			url:     href,
+			context: context,
+		})
+	})
+	return links, nil
+}
+
+// linkContext returns the text of the closest paragraph-like element around
+// a link. Layout tables and divs are only used when they are small, since in
+// many newsletters the whole issue is one table cell.
+func linkContext(a *goquery.Selection) string {
+	if block := a.Closest("p, li, blockquote, h1, h2, h3, h4"); block.Length() > 0 {
+		return collapseSpace(block.Text())
+	}
+	if block := a.Closest("td, div"); block.Length() > 0 {
+		if text := collapseSpace(block.Text()); len(text) <= 600 {
+			return text
+		}
+	}
+	return collapseSpace(a.Text())
+}
+
+// headingBefore returns the nearest heading above the link's block, for
+// newsletters that put the story title in a heading and end with "Read more".
+func headingBefore(a *goquery.Selection) string {
+	block := a.Closest("p, li, blockquote, td, div")
+	if block.Length() == 0 {
+		return ""
+	}
+	for s := block; s.Length() > 0; s = s.Parent() {
+		if h := s.PrevAllFiltered("h1, h2, h3, h4").First(); h.Length() > 0 {
+			return collapseSpace(h.Text())
+		}
+		if goquery.NodeName(s) == "body" {
+			break
+		}
+	}
+	return ""
+}
+
+var (
+	textURLPattern = regexp.MustCompile(`https?://[^\s<>"()\[\]]+`)
+	// "[1] ", "- ", "* ", "> " list and quote markers.
+	textMarkerPattern = regexp.MustCompile(`^(\[\d+\]|[-*>•]|\d+[.)])\s*`)
+)
+
+// textLinks extracts links from a plain-text newsletter. The link's title is
+// the text on its line, or the line above when the URL stands alone.
+func textLinks(body string) []newsletterLink {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+
+	var (
+		links []newsletterLink
+		seen  = make(map[string]bool)
+	)
+	for i, line := range lines {
+		for _, href := range textURLPattern.FindAllString(line, -1) {
+			href = strings.TrimRight(href, ".,;:!?'")
+			if !isStoryURL(href) || seen[href] {
+				continue
+			}
+
+			title := cleanTextLine(line)
+			if len(strings.Fields(title)) < 3 && i > 0 {
+				title = cleanTextLine(lines[i-1])
+			}
+			context := textParagraph(lines, i)
+			if skipLinkPattern.MatchString(href + " " + context) {
+				continue
+			}
+			if title == "" {
+				title = firstSentence(context)
+			}
+			if title == "" {
+				continue
+			}
+
+			seen[href] = true
+			links = append(links, newsletterLink{
+				title:   truncate(title, 200),
+				url:     href,
+				context: context,
+			})
+		}
+	}
+	return links
+}
+
+// textParagraph joins the lines of the blank-line separated paragraph
+// containing line i, without URLs.
+func textParagraph(lines []string, i int) string {
+	start, end := i, i
+	for start > 0 && strings.TrimSpace(lines[start-1]) != "" {
+		start--
+	}
+	for end < len(lines)-1 && strings.TrimSpace(lines[end+1]) != "" {
+		end++
+	}
+
+	var parts []string
+	for _, l := range lines[start : end+1] {
+		if l = cleanTextLine(l); l != "" {
+			parts = append(parts, l)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func cleanTextLine(line string) string {
+	line = textURLPattern.ReplaceAllString(line, "")
+	line = strings.NewReplacer("[]", "", "()", "", "<>", "").Replace(line)
+	line = collapseSpace(line)
+	line = textMarkerPattern.ReplaceAllString(line, "")
+	return strings.Trim(line, " :-–—|")
+}
+
+func isStoryURL(href string) bool {
+	u, err := url.Parse(href)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return false
+	}
+	return true
+}
+
+func isGenericLinkText(text string) bool {
+	t := strings.ToLower(strings.Trim(text, " .:!→»>[]()"))
+	return genericLinkText[t] || len(strings.Fields(t)) < 2 && len(t) < 12
+}
+
+func firstSentence(s string) string {
+	if i := strings.Index(s, ". "); i > 0 {
+		s = s[:i]
+	}
+	return truncate(s, 200)
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
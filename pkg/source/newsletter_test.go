@@ -0,0 +1,225 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/backend/memory"
+	"github.com/emersion/go-imap/client"
+	"github.com/emersion/go-imap/commands"
+	"github.com/emersion/go-imap/server"
+)
+
+const testNewsletter = "From: AI Weekly <news@aiweekly.example>\r\n" +
+	"To: reader@example.org\r\n" +
+	"Subject: AI Weekly #42\r\n" +
+	"Date: Mon, 12 Oct 2026 08:00:00 +0000\r\n" +
+	"Message-ID: <issue42@aiweekly.example>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/alternative; boundary=\"b1\"\r\n" +
+	"\r\n" +
+	"--b1\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"\r\n" +
+	"Open LLM tops the reasoning leaderboard https://example.com/open-llm\r\n" +
+	"--b1\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"\r\n" +
+	"<html><body>\r\n" +
+	"<p><a href=\"https://example.com/open-llm\">Open LLM tops the reasoning leaderboard</a></p>\r\n" +
+	"<p><a href=\"https://example.com/sourdough\">Ten sourdough recipes for autumn</a></p>\r\n" +
+	"<p>A new <a href=\"https://example.com/diffusion-video\">diffusion model for video</a> ships weights.</p>\r\n" +
+	"<p><a href=\"https://aiweekly.example/unsubscribe\">Unsubscribe</a></p>\r\n" +
+	"</body></html>\r\n" +
+	"--b1--\r\n"
+
+// startIMAP runs an in-memory IMAP server holding testNewsletter in INBOX
+// and returns its address. The memory backend's user is username/password.
+func startIMAP(t *testing.T) string {
+	t.Helper()
+
+	srv := server.New(memory.New())
+	srv.AllowInsecureAuth = true
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	go srv.Serve(l)
+	t.Cleanup(func() { srv.Close() })
+
+	c := dialIMAP(t, l.Addr().String())
+	if err := c.Append("INBOX", nil, time.Now(), bytes.NewBufferString(testNewsletter)); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if err := c.Create("Processed"); err != nil {
+		t.Fatalf("create mailbox: %v", err)
+	}
+	return l.Addr().String()
+}
+
+func dialIMAP(t *testing.T, addr string) *client.Client {
+	t.Helper()
+	c, err := client.Dial(addr)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { c.Logout() })
+	if err := c.Login("username", "password"); err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	return c
+}
+
+// newsletterFlags returns the flags of the test newsletter in mailbox, or
+// nil if it isn't there.
+func newsletterFlags(t *testing.T, addr, mailbox string) []string {
+	t.Helper()
+	c := dialIMAP(t, addr)
+	if _, err := c.Select(mailbox, true); err != nil {
+		t.Fatalf("select %s: %v", mailbox, err)
+	}
+
+	criteria := imap.NewSearchCriteria()
+	criteria.Header.Add("Subject", "AI Weekly")
+	uids, err := c.UidSearch(criteria)
+	if err != nil || len(uids) == 0 {
+		return nil
+	}
+
+	seqset := new(imap.SeqSet)
+	seqset.AddNum(uids...)
+	ch := make(chan *imap.Message, len(uids))
+	if err := c.UidFetch(seqset, []imap.FetchItem{imap.FetchFlags}, ch); err != nil {
+		t.Fatalf("fetch flags: %v", err)
+	}
+	msg := <-ch
+	return msg.Flags
+}
+
+// hasFlag reports whether flags contain flag; keywords are case-insensitive.
+func hasFlag(flags []string, flag string) bool {
+	return slices.ContainsFunc(flags, func(f string) bool { return strings.EqualFold(f, flag) })
+}
+
+func newTestNewsletter(addr string) *Newsletter {
+	return NewNewsletter(NewsletterOptions{
+		Addr:     addr,
+		Security: "none",
+		Username: "username",
+		Password: "password",
+	}, NewFilter(nil, nil))
+}
+
+func TestNewsletterCollect(t *testing.T) {
+	addr := startIMAP(t)
+	n := newTestNewsletter(addr)
+	ctx := context.Background()
+
+	items, err := n.Collect(ctx)
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+
+	var urls []string
+	for _, item := range items {
+		urls = append(urls, item.URL)
+		if item.Source != SourceNewsletter || item.Author != "AI Weekly" {
+			t.Errorf("item %s: source %q, author %q", item.URL, item.Source, item.Author)
+		}
+		if item.Extra["subject"] != "AI Weekly #42" {
+			t.Errorf("item %s: subject %v", item.URL, item.Extra["subject"])
+		}
+	}
+	slices.Sort(urls)
+	want := []string{"https://example.com/diffusion-video", "https://example.com/open-llm"}
+	if !slices.Equal(urls, want) {
+		t.Fatalf("collected %q, want the AI links %q", urls, want)
+	}
+
+	// Nothing is flagged until the items are stored.
+	if flags := newsletterFlags(t, addr, "INBOX"); hasFlag(flags, "$AiradarProcessed") {
+		t.Fatal("newsletter flagged before Commit")
+	}
+	again, err := n.Collect(ctx)
+	if err != nil {
+		t.Fatalf("Collect without Commit: %v", err)
+	}
+	if len(again) != len(items) {
+		t.Fatalf("Collect without Commit returned %d items, want %d again", len(again), len(items))
+	}
+
+	if err := n.Commit(ctx); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if flags := newsletterFlags(t, addr, "INBOX"); !hasFlag(flags, "$AiradarProcessed") {
+		t.Fatalf("newsletter flags %q, want $AiradarProcessed", flags)
+	}
+
+	items, err = n.Collect(ctx)
+	if err != nil {
+		t.Fatalf("second Collect: %v", err)
+	}
+	if len(items) != 0 {
+		t.Fatalf("second Collect returned %d items, want none", len(items))
+	}
+}
+
+func TestNewsletterMoveTo(t *testing.T) {
+	addr := startIMAP(t)
+	n := newTestNewsletter(addr)
+	n.opts.MoveTo = "Processed"
+	ctx := context.Background()
+
+	if _, err := n.Collect(ctx); err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	if err := n.Commit(ctx); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	moved := newsletterFlags(t, addr, "Processed")
+	if !hasFlag(moved, "$AiradarProcessed") {
+		t.Fatalf("moved newsletter flags %q, want $AiradarProcessed", moved)
+	}
+
+	// The memory backend advertises MOVE but refuses it, and has no
+	// UIDPLUS: the original is flagged deleted but not expunged, so
+	// messages the user deleted are never expunged by us either.
+	orig := newsletterFlags(t, addr, "INBOX")
+	if orig == nil {
+		t.Fatal("original expunged without UIDPLUS")
+	}
+	if !hasFlag(orig, imap.DeletedFlag) {
+		t.Fatalf("original flags %q, want %s", orig, imap.DeletedFlag)
+	}
+
+	items, err := n.Collect(ctx)
+	if err != nil {
+		t.Fatalf("second Collect: %v", err)
+	}
+	if len(items) != 0 {
+		t.Fatalf("second Collect returned %d items, want none", len(items))
+	}
+}
+
+func TestUIDExpungeCommand(t *testing.T) {
+	uids := new(imap.SeqSet)
+	uids.AddNum(3, 4, 5, 9)
+
+	var b bytes.Buffer
+	w := imap.NewClientWriter(&b, nil)
+	cmd := (&commands.Uid{Cmd: uidExpunge{uids}}).Command()
+	cmd.Tag = "a1"
+	if err := cmd.WriteTo(w); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if got := strings.TrimSpace(b.String()); got != "a1 UID EXPUNGE 3:5,9" {
+		t.Fatalf("command = %q, want a1 UID EXPUNGE 3:5,9", got)
+	}
+}
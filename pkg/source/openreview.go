@@ -0,0 +1,306 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	openReviewAPIURL  = "https://api2.openreview.net"
+	openReviewPageMax = 200 // notes per request
+)
+
+// OpenReview collects conference submissions from OpenReview venues, with
+// review ratings and decisions once they are public.
+type OpenReview struct {
+	client *http.Client
+	venues []string
+	limit  int
+}
+
+// NewOpenReview creates a new OpenReview collector.
+// venues are venue IDs such as "ICLR.cc/2026/Conference"; limit caps the
+// newest submissions fetched per venue and run.
+func NewOpenReview(venues []string, limit int) *OpenReview {
+	if limit <= 0 {
+		limit = 500
+	}
+	return &OpenReview{
+		client: newHTTPClient(60 * time.Second),
+		venues: venues,
+		limit:  limit,
+	}
+}
+
+func (o *OpenReview) Name() SourceType { return SourceOpenReview }
+
+func (o *OpenReview) Collect(ctx context.Context) ([]Item, error) {
+	if len(o.venues) == 0 {
+		return nil, fmt.Errorf("openreview: no venues configured")
+	}
+
+	var (
+		items   []Item
+		lastErr error
+		ok      int
+	)
+
+	for _, venue := range o.venues {
+		notes, err := o.fetchVenue(ctx, venue)
+		if err != nil {
+			fmt.Printf("  openreview %s error: %v\n", venue, err)
+			lastErr = err
+			continue
+		}
+		ok++
+
+		for _, note := range notes {
+			if item, valid := note.toItem(venue); valid {
+				items = append(items, item)
+			}
+		}
+	}
+
+	if ok == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return items, nil
+}
+
+// fetchVenue pages through a venue's submissions, newest first, including
+// their replies (reviews, decisions, comments).
+func (o *OpenReview) fetchVenue(ctx context.Context, venue string) ([]orNote, error) {
+	var notes []orNote
+
+	for offset := 0; offset < o.limit; offset += openReviewPageMax {
+		params := url.Values{}
+		params.Set("invitation", venue+"/-/Submission")
+		params.Set("details", "replies")
+		params.Set("sort", "cdate:desc")
+		params.Set("limit", strconv.Itoa(min(openReviewPageMax, o.limit-offset)))
+		params.Set("offset", strconv.Itoa(offset))
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, openReviewAPIURL+"/notes?"+params.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("create openreview request: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("User-Agent", "airadar/1.0")
+
+		resp, err := o.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("fetch openreview: %w", err)
+		}
+
+		var page struct {
+			Notes []orNote `json:"notes"`
+			Count int      `json:"count"`
+		}
+		if err := checkStatus(resp); err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("openreview: %w", err)
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decode openreview: %w", err)
+		}
+
+		notes = append(notes, page.Notes...)
+		if len(page.Notes) < openReviewPageMax || (page.Count > 0 && offset+len(page.Notes) >= page.Count) {
+			break
+		}
+	}
+
+	return notes, nil
+}
+
+// orNote is an OpenReview API v2 note. Content fields are wrapped as
+// {"value": ...}.
+type orNote struct {
+	ID      string             `json:"id"`
+	Forum   string             `json:"forum"`
+	Number  int                `json:"number"`
+	CDate   int64              `json:"cdate"`
+	PDate   int64              `json:"pdate"`
+	Content map[string]orValue `json:"content"`
+	Details struct {
+		Replies []orReply `json:"replies"`
+	} `json:"details"`
+}
+
+type orReply struct {
+	Invitations []string           `json:"invitations"`
+	Content     map[string]orValue `json:"content"`
+}
+
+type orValue struct {
+	Value json.RawMessage `json:"value"`
+}
+
+func (v orValue) String() string {
+	var s string
+	if json.Unmarshal(v.Value, &s) == nil {
+		return s
+	}
+	return strings.Trim(string(v.Value), `"`)
+}
+
+func (v orValue) Strings() []string {
+	var ss []string
+	json.Unmarshal(v.Value, &ss)
+	return ss
+}
+
+// Number reads numeric fields, including ratings written as
+// "8: accept, good paper".
+func (v orValue) Number() (float64, bool) {
+	var f float64
+	if json.Unmarshal(v.Value, &f) == nil {
+		return f, true
+	}
+	s := v.String()
+	if i := strings.IndexAny(s, ": "); i > 0 {
+		s = s[:i]
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return f, err == nil
+}
+
+// replyKind returns the invitation suffix of a reply, e.g. "Official_Review"
+// or "Decision".
+func (r orReply) replyKind() string {
+	for _, inv := range r.Invitations {
+		if i := strings.LastIndex(inv, "/-/"); i >= 0 {
+			return inv[i+3:]
+		}
+	}
+	return ""
+}
+
+func (n orNote) toItem(venue string) (Item, bool) {
+	title := n.Content["title"].String()
+	if n.ID == "" || title == "" {
+		return Item{}, false
+	}
+
+	var (
+		ratings     []float64
+		confidences []float64
+		decision    string
+	)
+	for _, r := range n.Details.Replies {
+		switch r.replyKind() {
+		case "Official_Review":
+			if f, ok := r.Content["rating"].Number(); ok {
+				ratings = append(ratings, f)
+			}
+			if f, ok := r.Content["confidence"].Number(); ok {
+				confidences = append(confidences, f)
+			}
+		case "Decision":
+			decision = r.Content["decision"].String()
+		}
+	}
+
+	// venueid moves from "<venue>/Submission" to "<venue>" on acceptance or
+	// "<venue>/Rejected_Submission", "<venue>/Withdrawn_Submission", ...
+	status := n.Content["venueid"].String()
+	if status == venue {
+		status = "Accepted"
+	}
+	status = strings.TrimPrefix(status, venue+"/")
+
+	extra := map[string]any{
+		"venue_id": venue,
+		"venue":    n.Content["venue"].String(),
+		"status":   status,
+		"number":   n.Number,
+		"reviews":  len(ratings),
+	}
+	score := 0
+	if len(ratings) > 0 {
+		avg := mean(ratings)
+		extra["ratings"] = ratings
+		extra["avg_rating"] = math.Round(avg*100) / 100
+		// Score is the mean rating x10 so it is an integer: 6.5 -> 65.
+		score = int(math.Round(avg * 10))
+	}
+	if len(confidences) > 0 {
+		extra["avg_confidence"] = math.Round(mean(confidences)*100) / 100
+	}
+	if decision != "" {
+		extra["decision"] = decision
+	}
+	if pdf := n.Content["pdf"].String(); pdf != "" {
+		extra["pdf"] = "https://openreview.net" + pdf
+	}
+
+	// Authors are hidden while a venue is double-blind.
+	author := strings.Join(n.Content["authors"].Strings(), ", ")
+
+	created := n.PDate
+	if created == 0 {
+		created = n.CDate
+	}
+	published := time.Now().UTC()
+	if created > 0 {
+		published = time.UnixMilli(created).UTC()
+	}
+
+	forum := n.Forum
+	if forum == "" {
+		forum = n.ID
+	}
+
+	return Item{
+		ID:          fmt.Sprintf("openreview:%s", n.ID),
+		Source:      SourceOpenReview,
+		ExternalID:  n.ID,
+		Title:       title,
+		URL:         "https://openreview.net/forum?id=" + forum,
+		Description: truncate(n.Content["abstract"].String(), 500),
+		Author:      author,
+		Score:       score,
+		Comments:    len(n.Details.Replies),
+		Tags:        n.Content["keywords"].Strings(),
+		PublishedAt: published,
+		CollectedAt: time.Now().UTC(),
+		Extra:       extra,
+	}, true
+}
+
+func mean(xs []float64) float64 {
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+// OpenReviewConfig for OpenReview conference collector.
+type OpenReviewConfig struct {
+	Enabled bool     `yaml:"enabled"`
+	Venues  []string `yaml:"venues"` // venue IDs, e.g. "ICLR.cc/2026/Conference"
+	Limit   int      `yaml:"limit"`  // newest submissions per venue and run
+}
+
+func init() {
+	Register(Factory[OpenReviewConfig]{
+		Type: SourceOpenReview,
+		Defaults: func() OpenReviewConfig {
+			return OpenReviewConfig{Enabled: false, Limit: 500}
+		},
+		Enabled: func(c OpenReviewConfig) bool { return c.Enabled },
+		New: func(c OpenReviewConfig, deps Deps) ([]Source, error) {
+			return []Source{NewOpenReview(c.Venues, c.Limit)}, nil
+		},
+	})
+}
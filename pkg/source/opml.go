@@ -0,0 +1,70 @@
+package source
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ParseOPML extracts RSS feeds from an OPML document, as exported by most
+// feed readers. Nested category outlines are flattened.
+func ParseOPML(r io.Reader) ([]RSSFeed, error) {
+	var doc opmlDoc
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decode opml: %w", err)
+	}
+
+	var feeds []RSSFeed
+	var walk func([]opmlOutline)
+	walk = func(outlines []opmlOutline) {
+		for _, o := range outlines {
+			if o.XMLURL != "" {
+				name := strings.TrimSpace(o.Title)
+				if name == "" {
+					name = strings.TrimSpace(o.Text)
+				}
+				if name == "" {
+					name = o.XMLURL
+				}
+				feeds = append(feeds, RSSFeed{Name: name, URL: strings.TrimSpace(o.XMLURL)})
+			}
+			walk(o.Outlines)
+		}
+	}
+	walk(doc.Body.Outlines)
+
+	return feeds, nil
+}
+
+// LoadOPML reads feeds from an OPML file on disk.
+func LoadOPML(path string) ([]RSSFeed, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open opml %s: %w", path, err)
+	}
+	defer f.Close()
+
+	feeds, err := ParseOPML(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return feeds, nil
+}
+
+type opmlDoc struct {
+	XMLName xml.Name `xml:"opml"`
+	Body    struct {
+		Outlines []opmlOutline `xml:"outline"`
+	} `xml:"body"`
+}
+
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr"`
+	Type     string        `xml:"type,attr"`
+	XMLURL   string        `xml:"xmlUrl,attr"`
+	HTMLURL  string        `xml:"htmlUrl,attr"`
+	Outlines []opmlOutline `xml:"outline"`
+}
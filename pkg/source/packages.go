@@ -0,0 +1,557 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+const (
+	pypiURL      = "https://pypi.org"
+	pypiStatsURL = "https://pypistats.org/api"
+	npmRegistry  = "https://registry.npmjs.org"
+	npmAPIURL    = "https://api.npmjs.org"
+)
+
+// PackagesOptions configures the package registry collector.
+type PackagesOptions struct {
+	PyPI []string // PyPI project names
+	// NPM holds npm package names; "@scope/*" expands to every package in
+	// the scope.
+	NPM  []string
+	Days int // report versions published this recently (default: 7)
+
+	Downloads  bool     // look up download counts (pypistats.org, api.npmjs.org)
+	Discover   bool     // report new packages matching the AI filter
+	NPMQueries []string // npm search terms for discovery (default: llm, ai sdk, mcp)
+}
+
+// Packages collects new versions of watched PyPI and npm packages and,
+// optionally, newly published AI packages.
+type Packages struct {
+	client *http.Client
+	opts   PackagesOptions
+	filter *Filter
+}
+
+// NewPackages creates a new package registry collector.
+func NewPackages(opts PackagesOptions, filter *Filter) *Packages {
+	if opts.Days <= 0 {
+		opts.Days = 7
+	}
+	if len(opts.NPMQueries) == 0 {
+		opts.NPMQueries = []string{"llm", "ai sdk", "mcp"}
+	}
+	return &Packages{
+		client: newHTTPClient(30 * time.Second),
+		opts:   opts,
+		filter: filter,
+	}
+}
+
+func (p *Packages) Name() SourceType { return SourcePackages }
+
+func (p *Packages) Collect(ctx context.Context) ([]Item, error) {
+	cutoff := time.Now().AddDate(0, 0, -p.opts.Days)
+
+	var (
+		items   []Item
+		lastErr error
+		ok      int
+	)
+	record := func(what string, found []Item, err error) {
+		if err != nil {
+			fmt.Printf("  packages %s error: %v\n", what, err)
+			lastErr = err
+			return
+		}
+		ok++
+		items = append(items, found...)
+	}
+
+	for _, name := range p.opts.PyPI {
+		found, err := p.pypiVersions(ctx, name, cutoff)
+		record("pypi "+name, found, err)
+	}
+
+	npmNames, err := p.expandNPM(ctx)
+	if err != nil {
+		fmt.Printf("  packages npm scope error: %v\n", err)
+		lastErr = err
+	}
+	for _, name := range npmNames {
+		found, err := p.npmVersions(ctx, name, cutoff)
+		record("npm "+name, found, err)
+	}
+
+	if p.opts.Discover {
+		found, err := p.discoverPyPI(ctx)
+		record("pypi discovery", found, err)
+
+		found, err = p.discoverNPM(ctx, cutoff)
+		record("npm discovery", found, err)
+	}
+
+	if ok == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return items, nil
+}
+
+// pypiVersions returns the versions of a PyPI project uploaded after cutoff.
+func (p *Packages) pypiVersions(ctx context.Context, name string, cutoff time.Time) ([]Item, error) {
+	var project struct {
+		Info struct {
+			Name        string            `json:"name"`
+			Summary     string            `json:"summary"`
+			Author      string            `json:"author"`
+			ProjectURLs map[string]string `json:"project_urls"`
+		} `json:"info"`
+		Releases map[string][]struct {
+			UploadTime time.Time `json:"upload_time_iso_8601"`
+			Yanked     bool      `json:"yanked"`
+		} `json:"releases"`
+	}
+	if err := p.getJSON(ctx, fmt.Sprintf("%s/pypi/%s/json", pypiURL, url.PathEscape(name)), &project); err != nil {
+		return nil, err
+	}
+
+	var downloads map[string]any
+	var items []Item
+	for version, files := range project.Releases {
+		// A version's release date is its first file upload.
+		var released time.Time
+		yanked := len(files) > 0
+		for _, f := range files {
+			if released.IsZero() || f.UploadTime.Before(released) {
+				released = f.UploadTime
+			}
+			yanked = yanked && f.Yanked
+		}
+		if released.IsZero() || !released.After(cutoff) || yanked {
+			continue
+		}
+
+		if downloads == nil && p.opts.Downloads {
+			downloads = p.pypiDownloads(ctx, name)
+		}
+
+		items = append(items, packageItem(packageVersion{
+			Registry:    "pypi",
+			Name:        project.Info.Name,
+			Version:     version,
+			Summary:     project.Info.Summary,
+			Author:      project.Info.Author,
+			URL:         fmt.Sprintf("%s/project/%s/%s/", pypiURL, project.Info.Name, version),
+			Homepage:    projectHomepage(project.Info.ProjectURLs),
+			PublishedAt: released,
+			Downloads:   downloads,
+		}))
+	}
+	return items, nil
+}
+
+// pypiDownloads returns recent download counts, or nil if pypistats has none.
+func (p *Packages) pypiDownloads(ctx context.Context, name string) map[string]any {
+	var stats struct {
+		Data struct {
+			LastDay   int `json:"last_day"`
+			LastWeek  int `json:"last_week"`
+			LastMonth int `json:"last_month"`
+		} `json:"data"`
+	}
+	if err := p.getJSON(ctx, fmt.Sprintf("%s/packages/%s/recent", pypiStatsURL, url.PathEscape(strings.ToLower(name))), &stats); err != nil {
+		return nil
+	}
+	return map[string]any{
+		"downloads_last_day":   stats.Data.LastDay,
+		"downloads_last_week":  stats.Data.LastWeek,
+		"downloads_last_month": stats.Data.LastMonth,
+	}
+}
+
+// discoverPyPI checks PyPI's newest-projects feed against the AI filter.
+func (p *Packages) discoverPyPI(ctx context.Context) ([]Item, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pypiURL+"/rss/packages.xml", nil)
+	if err != nil {
+		return nil, fmt.Errorf("create pypi feed request: %w", err)
+	}
+	req.Header.Set("User-Agent", "airadar/1.0")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch pypi feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return nil, fmt.Errorf("pypi feed: %w", err)
+	}
+
+	feed, err := gofeed.NewParser().Parse(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parse pypi feed: %w", err)
+	}
+
+	var items []Item
+	for _, entry := range feed.Items {
+		// Titles read "<name> added to PyPI".
+		name := strings.TrimSpace(strings.TrimSuffix(entry.Title, " added to PyPI"))
+		if name == "" || !p.matches(name, entry.Description) {
+			continue
+		}
+
+		published := time.Now().UTC()
+		if entry.PublishedParsed != nil {
+			published = entry.PublishedParsed.UTC()
+		}
+
+		author := ""
+		if entry.Author != nil {
+			author = entry.Author.Name
+		}
+
+		items = append(items, packageItem(packageVersion{
+			Registry:    "pypi",
+			Name:        name,
+			Summary:     entry.Description,
+			Author:      author,
+			URL:         entry.Link,
+			PublishedAt: published,
+		}))
+	}
+	return items, nil
+}
+
+// expandNPM resolves "@scope/*" entries into the scope's packages.
+func (p *Packages) expandNPM(ctx context.Context) ([]string, error) {
+	var (
+		names   []string
+		seen    = make(map[string]bool)
+		lastErr error
+	)
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	for _, name := range p.opts.NPM {
+		scope, ok := strings.CutSuffix(name, "/*")
+		if !ok {
+			add(name)
+			continue
+		}
+
+		results, err := p.npmSearch(ctx, "scope:"+strings.TrimPrefix(scope, "@"))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		for _, r := range results {
+			if strings.HasPrefix(r.Package.Name, scope+"/") {
+				add(r.Package.Name)
+			}
+		}
+	}
+	return names, lastErr
+}
+
+// npmVersions returns the versions of an npm package published after cutoff.
+func (p *Packages) npmVersions(ctx context.Context, name string, cutoff time.Time) ([]Item, error) {
+	var doc struct {
+		Name        string               `json:"name"`
+		Description string               `json:"description"`
+		Time        map[string]time.Time `json:"time"`
+		Author      struct {
+			Name string `json:"name"`
+		} `json:"author"`
+		Homepage string            `json:"homepage"`
+		DistTags map[string]string `json:"dist-tags"`
+	}
+	if err := p.getJSON(ctx, npmRegistry+"/"+npmEscape(name), &doc); err != nil {
+		return nil, err
+	}
+
+	var downloads map[string]any
+	var items []Item
+	for version, published := range doc.Time {
+		if version == "created" || version == "modified" || !published.After(cutoff) {
+			continue
+		}
+
+		if downloads == nil && p.opts.Downloads {
+			downloads = p.npmDownloads(ctx, name)
+		}
+
+		items = append(items, packageItem(packageVersion{
+			Registry:    "npm",
+			Name:        doc.Name,
+			Version:     version,
+			Summary:     doc.Description,
+			Author:      doc.Author.Name,
+			URL:         fmt.Sprintf("https://www.npmjs.com/package/%s/v/%s", doc.Name, version),
+			Homepage:    doc.Homepage,
+			PublishedAt: published,
+			Prerelease:  strings.Contains(version, "-"),
+			Downloads:   downloads,
+		}))
+	}
+	return items, nil
+}
+
+// npmDownloads returns last-day and last-week download counts, or nil.
+func (p *Packages) npmDownloads(ctx context.Context, name string) map[string]any {
+	counts := make(map[string]any)
+	for _, period := range []string{"last-day", "last-week"} {
+		var point struct {
+			Downloads int `json:"downloads"`
+		}
+		if err := p.getJSON(ctx, fmt.Sprintf("%s/downloads/point/%s/%s", npmAPIURL, period, name), &point); err != nil {
+			return nil
+		}
+		counts["downloads_"+strings.ReplaceAll(period, "-", "_")] = point.Downloads
+	}
+	return counts
+}
+
+// discoverNPM searches npm for recently published packages that match the
+// AI filter.
+func (p *Packages) discoverNPM(ctx context.Context, cutoff time.Time) ([]Item, error) {
+	var (
+		items []Item
+		seen  = make(map[string]bool)
+	)
+	for _, q := range p.opts.NPMQueries {
+		results, err := p.npmSearch(ctx, q)
+		if err != nil {
+			return items, err
+		}
+		for _, r := range results {
+			pkg := r.Package
+			if seen[pkg.Name] || !pkg.Date.After(cutoff) || !p.matches(pkg.Name, pkg.Description) {
+				continue
+			}
+			seen[pkg.Name] = true
+
+			items = append(items, packageItem(packageVersion{
+				Registry:    "npm",
+				Name:        pkg.Name,
+				Summary:     pkg.Description,
+				Author:      pkg.Publisher.Username,
+				URL:         "https://www.npmjs.com/package/" + pkg.Name,
+				Homepage:    pkg.Links.Homepage,
+				PublishedAt: pkg.Date,
+				Keywords:    pkg.Keywords,
+			}))
+		}
+	}
+	return items, nil
+}
+
+func (p *Packages) npmSearch(ctx context.Context, text string) ([]npmSearchResult, error) {
+	params := url.Values{}
+	params.Set("text", text)
+	params.Set("size", "250")
+
+	var result struct {
+		Objects []npmSearchResult `json:"objects"`
+	}
+	if err := p.getJSON(ctx, npmRegistry+"/-/v1/search?"+params.Encode(), &result); err != nil {
+		return nil, err
+	}
+	return result.Objects, nil
+}
+
+func (p *Packages) matches(name, description string) bool {
+	if p.filter == nil {
+		return true
+	}
+	return p.filter.MatchesAI(strings.NewReplacer("-", " ", "_", " ", "/", " ", "@", "").Replace(name) + " " + description)
+}
+
+func (p *Packages) getJSON(ctx context.Context, reqURL string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("create packages request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "airadar/1.0")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch %s: %w", req.URL.Host, err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return fmt.Errorf("%s: %w", req.URL.Host, err)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode %s: %w", req.URL.Host, err)
+	}
+	return nil
+}
+
+// npmEscape encodes a package name for the registry: the scope's slash must
+// be escaped ("@ai-sdk%2Fopenai").
+func npmEscape(name string) string {
+	if scope, pkg, ok := strings.Cut(name, "/"); ok && strings.HasPrefix(scope, "@") {
+		return scope + "%2F" + url.PathEscape(pkg)
+	}
+	return url.PathEscape(name)
+}
+
+// projectHomepage picks the most useful link from PyPI's project_urls.
+func projectHomepage(urls map[string]string) string {
+	for _, key := range []string{"Homepage", "homepage", "Source", "Repository", "Source Code"} {
+		if u := urls[key]; u != "" {
+			return u
+		}
+	}
+	keys := make([]string, 0, len(urls))
+	for k := range urls {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if len(keys) > 0 {
+		return urls[keys[0]]
+	}
+	return ""
+}
+
+type npmSearchResult struct {
+	Package struct {
+		Name        string    `json:"name"`
+		Description string    `json:"description"`
+		Keywords    []string  `json:"keywords"`
+		Date        time.Time `json:"date"`
+		Publisher   struct {
+			Username string `json:"username"`
+		} `json:"publisher"`
+		Links struct {
+			Homepage string `json:"homepage"`
+		} `json:"links"`
+	} `json:"package"`
+}
+
+// packageVersion is a release of a package, or a newly discovered package
+// when Version is empty.
+type packageVersion struct {
+	Registry    string
+	Name        string
+	Version     string
+	Summary     string
+	Author      string
+	URL         string
+	Homepage    string
+	PublishedAt time.Time
+	Prerelease  bool
+	Keywords    []string
+	Downloads   map[string]any
+}
+
+func packageItem(v packageVersion) Item {
+	externalID := v.Registry + ":" + v.Name
+	title := v.Name
+	tags := []string{v.Registry}
+	if v.Version != "" {
+		externalID += "@" + v.Version
+		title += " " + v.Version
+		tags = append(tags, "release")
+	} else {
+		tags = append(tags, "new-package")
+	}
+	if v.Prerelease {
+		tags = append(tags, "prerelease")
+	}
+	tags = append(tags, v.Keywords...)
+
+	extra := map[string]any{
+		"registry": v.Registry,
+		"package":  v.Name,
+		"summary":  v.Summary,
+	}
+	if v.Version != "" {
+		extra["version"] = v.Version
+		extra["prerelease"] = v.Prerelease
+	}
+	if v.Homepage != "" {
+		extra["homepage"] = v.Homepage
+		if repo := GitHubRepoFromURL(v.Homepage); repo != "" {
+			extra["code_repo"] = repo
+		}
+	}
+	for k, n := range v.Downloads {
+		extra[k] = n
+	}
+
+	author := v.Author
+	if author == "" {
+		// Scoped npm packages belong to their scope.
+		if scope, _, ok := strings.Cut(v.Name, "/"); ok {
+			author = scope
+		}
+	}
+
+	return Item{
+		ID:          "packages:" + externalID,
+		Source:      SourcePackages,
+		ExternalID:  externalID,
+		Title:       title,
+		URL:         v.URL,
+		Description: truncate(v.Summary, 500),
+		Author:      author,
+		Tags:        tags,
+		PublishedAt: v.PublishedAt.UTC(),
+		CollectedAt: time.Now().UTC(),
+		Extra:       extra,
+	}
+}
+
+// PackagesConfig for the PyPI / npm release collector.
+type PackagesConfig struct {
+	Enabled    bool     `yaml:"enabled"`
+	PyPI       []string `yaml:"pypi"`
+	NPM        []string `yaml:"npm"`         // "@scope/*" watches a whole scope
+	Days       int      `yaml:"days"`        // report versions published this recently
+	Downloads  bool     `yaml:"downloads"`   // look up download counts
+	Discover   bool     `yaml:"discover"`    // report new packages matching the AI filter
+	NPMQueries []string `yaml:"npm_queries"` // npm search terms for discovery
+}
+
+func init() {
+	Register(Factory[PackagesConfig]{
+		Type: SourcePackages,
+		Defaults: func() PackagesConfig {
+			return PackagesConfig{
+				Enabled:    true,
+				PyPI:       []string{"openai", "anthropic", "langchain", "transformers", "llama-index"},
+				NPM:        []string{"openai", "@anthropic-ai/sdk", "langchain", "ai", "@ai-sdk/*"},
+				Days:       7,
+				Downloads:  true,
+				Discover:   true,
+				NPMQueries: []string{"llm", "ai sdk", "mcp"},
+			}
+		},
+		Enabled: func(c PackagesConfig) bool { return c.Enabled },
+		New: func(c PackagesConfig, deps Deps) ([]Source, error) {
+			return []Source{NewPackages(PackagesOptions{
+				PyPI:       c.PyPI,
+				NPM:        c.NPM,
+				Days:       c.Days,
+				Downloads:  c.Downloads,
+				Discover:   c.Discover,
+				NPMQueries: c.NPMQueries,
+			}, deps.Filter)}, nil
+		},
+	})
+}
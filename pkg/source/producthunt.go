@@ -0,0 +1,271 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+const phGraphQLURL = "https://api.producthunt.com/v2/api/graphql"
+
+const phPostsQuery = `query($topic: String, $postedAfter: DateTime, $after: String) {
+  posts(order: VOTES, topic: $topic, postedAfter: $postedAfter, first: 20, after: $after) {
+    edges {
+      node {
+        id name tagline description url website slug
+        votesCount commentsCount createdAt featuredAt
+        makers { name username }
+        topics { edges { node { name slug } } }
+      }
+    }
+    pageInfo { endCursor hasNextPage }
+  }
+}`
+
+// ProductHunt collects AI product launches from Product Hunt.
+type ProductHunt struct {
+	client   *http.Client
+	token    string
+	topics   []string
+	maxPages int
+}
+
+// NewProductHunt creates a new Product Hunt collector.
+// topics are Product Hunt topic slugs (e.g. "artificial-intelligence").
+func NewProductHunt(token string, topics []string) *ProductHunt {
+	if len(topics) == 0 {
+		topics = []string{"artificial-intelligence"}
+	}
+	return &ProductHunt{
+		client:   newHTTPClient(30 * time.Second),
+		token:    token,
+		topics:   topics,
+		maxPages: 3,
+	}
+}
+
+func (p *ProductHunt) Name() SourceType { return SourceProductHunt }
+
+func (p *ProductHunt) Collect(ctx context.Context) ([]Item, error) {
+	if p.token == "" {
+		return nil, fmt.Errorf("producthunt: developer token required (set PRODUCTHUNT_TOKEN)")
+	}
+
+	var (
+		allItems []Item
+		ok       int
+		lastErr  error
+	)
+	seen := make(map[string]bool)
+
+	for _, topic := range p.topics {
+		items, err := p.fetchTopic(ctx, topic)
+		if err != nil {
+			fmt.Printf("  producthunt topic %s error: %v\n", topic, err)
+			lastErr = err
+			continue
+		}
+		ok++
+		for _, item := range items {
+			if !seen[item.ExternalID] {
+				seen[item.ExternalID] = true
+				allItems = append(allItems, item)
+			}
+		}
+	}
+
+	if ok == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return allItems, nil
+}
+
+// fetchTopic returns posts in a topic launched in the last 24 hours.
+func (p *ProductHunt) fetchTopic(ctx context.Context, topic string) ([]Item, error) {
+	postedAfter := time.Now().Add(-24 * time.Hour).UTC().Format(time.RFC3339)
+
+	var (
+		items []Item
+		after string
+	)
+
+	for page := 0; page < p.maxPages; page++ {
+		vars := map[string]any{
+			"topic":       topic,
+			"postedAfter": postedAfter,
+		}
+		if after != "" {
+			vars["after"] = after
+		}
+
+		var result phPostsResult
+		if err := p.query(ctx, vars, &result); err != nil {
+			return nil, err
+		}
+
+		for _, edge := range result.Data.Posts.Edges {
+			post := edge.Node
+
+			var makers []string
+			for _, m := range post.Makers {
+				makers = append(makers, m.Username)
+			}
+
+			var topics []string
+			for _, t := range post.Topics.Edges {
+				topics = append(topics, t.Node.Name)
+			}
+
+			author := ""
+			if len(makers) > 0 {
+				author = makers[0]
+			}
+
+			title := post.Name
+			if post.Tagline != "" {
+				title = post.Name + ": " + post.Tagline
+			}
+
+			items = append(items, Item{
+				ID:          fmt.Sprintf("producthunt:%s", post.ID),
+				Source:      SourceProductHunt,
+				ExternalID:  post.ID,
+				Title:       title,
+				URL:         post.URL,
+				Description: truncate(post.Description, 500),
+				Author:      author,
+				Score:       post.VotesCount,
+				Comments:    post.CommentsCount,
+				Tags:        topics,
+				PublishedAt: post.CreatedAt.UTC(),
+				CollectedAt: time.Now().UTC(),
+				Extra: map[string]any{
+					"website":  post.Website,
+					"slug":     post.Slug,
+					"makers":   post.Makers,
+					"topics":   topics,
+					"featured": post.FeaturedAt != nil,
+				},
+			})
+		}
+
+		info := result.Data.Posts.PageInfo
+		if !info.HasNextPage || info.EndCursor == "" {
+			break
+		}
+		after = info.EndCursor
+	}
+
+	return items, nil
+}
+
+func (p *ProductHunt) query(ctx context.Context, vars map[string]any, out *phPostsResult) error {
+	body, err := json.Marshal(map[string]any{
+		"query":     phPostsQuery,
+		"variables": vars,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal producthunt query: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, phGraphQLURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create producthunt request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch producthunt: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return fmt.Errorf("producthunt: %w", err)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode producthunt response: %w", err)
+	}
+	if len(out.Errors) > 0 {
+		return fmt.Errorf("producthunt graphql: %s", out.Errors[0].Message)
+	}
+	return nil
+}
+
+type phPostsResult struct {
+	Data struct {
+		Posts struct {
+			Edges []struct {
+				Node phPost `json:"node"`
+			} `json:"edges"`
+			PageInfo struct {
+				EndCursor   string `json:"endCursor"`
+				HasNextPage bool   `json:"hasNextPage"`
+			} `json:"pageInfo"`
+		} `json:"posts"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+type phPost struct {
+	ID            string     `json:"id"`
+	Name          string     `json:"name"`
+	Tagline       string     `json:"tagline"`
+	Description   string     `json:"description"`
+	URL           string     `json:"url"`
+	Website       string     `json:"website"`
+	Slug          string     `json:"slug"`
+	VotesCount    int        `json:"votesCount"`
+	CommentsCount int        `json:"commentsCount"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	FeaturedAt    *time.Time `json:"featuredAt"`
+	Makers        []phMaker  `json:"makers"`
+	Topics        struct {
+		Edges []struct {
+			Node struct {
+				Name string `json:"name"`
+				Slug string `json:"slug"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"topics"`
+}
+
+type phMaker struct {
+	Name     string `json:"name"`
+	Username string `json:"username"`
+}
+
+// ProductHuntConfig for Product Hunt collector.
+type ProductHuntConfig struct {
+	Enabled bool     `yaml:"enabled"`
+	Token   string   `yaml:"token"`
+	Topics  []string `yaml:"topics"` // topic slugs
+}
+
+func init() {
+	Register(Factory[ProductHuntConfig]{
+		Type:    SourceProductHunt,
+		Aliases: []string{"ph"},
+		Defaults: func() ProductHuntConfig {
+			return ProductHuntConfig{Enabled: false, Topics: []string{"artificial-intelligence"}}
+		},
+		Env: func(c *ProductHuntConfig) {
+			if v := os.Getenv("PRODUCTHUNT_TOKEN"); v != "" {
+				c.Token = v
+			}
+		},
+		Enabled: func(c ProductHuntConfig) bool { return c.Enabled },
+		New: func(c ProductHuntConfig, deps Deps) ([]Source, error) {
+			return []Source{NewProductHunt(c.Token, c.Topics)}, nil
+		},
+	})
+}
@@ -6,35 +6,59 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 )
 
+// Reddit API endpoints, replaced in tests.
+var (
+	redditTokenURL = "https://www.reddit.com/api/v1/access_token"
+	redditAPIURL   = "https://oauth.reddit.com"
+)
+
+// redditListingCap is the most posts Reddit serves from one listing, however
+// far it is paged.
+const redditListingCap = 1000
+
 // Reddit collects AI-related posts from Reddit subreddits.
 type Reddit struct {
 	client       *http.Client
 	clientID     string
 	clientSecret string
 	subreddits   []string
+	listings     []string
+	maxPerSub    int
 	mu           sync.Mutex
 	token        string
 	tokenExpiry  time.Time
 }
 
 // NewReddit creates a new Reddit collector.
-func NewReddit(clientID, clientSecret string, subreddits []string) *Reddit {
+// listings are subreddit listings to read, e.g. "hot", "rising", "new" or
+// "top?t=day". maxPerSub caps the posts fetched per subreddit and listing.
+func NewReddit(clientID, clientSecret string, subreddits, listings []string, maxPerSub int) *Reddit {
 	if len(subreddits) == 0 {
 		subreddits = []string{
 			"MachineLearning", "artificial", "LocalLLM",
 			"singularity", "ChatGPT", "StableDiffusion",
 		}
 	}
+	if len(listings) == 0 {
+		listings = []string{"hot"}
+	}
+	if maxPerSub <= 0 {
+		maxPerSub = 50
+	}
 	return &Reddit{
-		client:       &http.Client{Timeout: 30 * time.Second},
+		client:       newHTTPClient(30 * time.Second),
 		clientID:     clientID,
 		clientSecret: clientSecret,
 		subreddits:   subreddits,
+		listings:     listings,
+		maxPerSub:    maxPerSub,
 	}
 }
 
@@ -44,18 +68,116 @@ func (r *Reddit) Collect(ctx context.Context) ([]Item, error) {
 	if err := r.authenticate(ctx); err != nil {
 		return nil, fmt.Errorf("reddit auth: %w", err)
 	}
+	items, _, err := r.collect(ctx, r.listings, r.maxPerSub, nil)
+	return items, err
+}
+
+// Backfill returns the top posts of each subreddit published between from
+// and to. Reddit has no date search, so it reads the top listing of the
+// shortest period reaching back to from, up to the 1000 posts Reddit serves
+// per listing, and keeps the posts inside the range. Ranges starting more
+// than a year back are rejected: the all-time listing would only return the
+// few top posts of all time. A listing cut off at 1000 posts misses the
+// lower-scored posts of the range, which is reported.
+func (r *Reddit) Backfill(ctx context.Context, from, to time.Time) ([]Item, error) {
+	period := ""
+	age := time.Since(from)
+	for _, p := range []struct {
+		name string
+		d    time.Duration
+	}{
+		{"day", 24 * time.Hour},
+		{"week", 7 * 24 * time.Hour},
+		{"month", 31 * 24 * time.Hour},
+		{"year", 365 * 24 * time.Hour},
+	} {
+		if age <= p.d {
+			period = p.name
+			break
+		}
+	}
+	if period == "" {
+		return nil, fmt.Errorf("reddit: top listings reach back a year, not to %s", from.Format("2006-01-02"))
+	}
+
+	if err := r.authenticate(ctx); err != nil {
+		return nil, fmt.Errorf("reddit auth: %w", err)
+	}
+
+	listing := "top?t=" + period
+	items, capped, err := r.collect(ctx, []string{listing}, redditListingCap, func(post redditPost) bool {
+		published := time.Unix(int64(post.CreatedUTC), 0)
+		return !published.Before(from) && published.Before(to)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, sub := range capped {
+		fmt.Printf("  reddit r/%s/%s: listing ends at Reddit's %d-post cap, lower-scored posts since %s are missing\n",
+			sub, listing, redditListingCap, from.Format("2006-01-02"))
+	}
+	return items, nil
+}
+
+// collect reads the listings of every subreddit. keep, if set, selects the
+// posts to return. capped lists the subreddits whose listing was cut off at
+// limit. It fails only when no listing could be read.
+func (r *Reddit) collect(ctx context.Context, listings []string, limit int, keep func(redditPost) bool) (items []Item, capped []string, err error) {
+	// Posts are keyed by their original post ID, so a crosspost and its
+	// parent (or the same post in several listings) become one item.
+	byID := make(map[string]*Item)
+	var (
+		order   []string
+		ok      int
+		lastErr error
+	)
 
-	var allItems []Item
 	for _, sub := range r.subreddits {
-		items, err := r.fetchSubreddit(ctx, sub)
-		if err != nil {
-			fmt.Printf("  reddit r/%s error: %v\n", sub, err)
-			continue
+		for _, listing := range listings {
+			posts, err := r.fetchListing(ctx, sub, listing, limit)
+			if err != nil {
+				fmt.Printf("  reddit r/%s/%s error: %v\n", sub, listing, err)
+				lastErr = err
+				continue
+			}
+			ok++
+			if len(posts) >= limit {
+				capped = append(capped, sub)
+			}
+
+			for _, post := range posts {
+				if post.Stickied || (keep != nil && !keep(post)) {
+					continue
+				}
+
+				orig := post
+				if post.CrosspostParent != "" && len(post.CrosspostParentList) > 0 {
+					orig = post.CrosspostParentList[0]
+				}
+
+				item, ok := byID[orig.ID]
+				if !ok {
+					item = redditItem(orig)
+					byID[orig.ID] = item
+					order = append(order, orig.ID)
+				}
+				addSubreddit(item, post.Subreddit)
+				if post.ID != orig.ID {
+					addCrosspost(item, post.ID)
+				}
+			}
 		}
-		allItems = append(allItems, items...)
 	}
 
-	return allItems, nil
+	if ok == 0 && lastErr != nil {
+		return nil, nil, lastErr
+	}
+
+	items = make([]Item, 0, len(order))
+	for _, id := range order {
+		items = append(items, *byID[id])
+	}
+	return items, capped, nil
 }
 
 func (r *Reddit) authenticate(ctx context.Context) error {
@@ -68,7 +190,7 @@ func (r *Reddit) authenticate(ctx context.Context) error {
 
 	data := url.Values{"grant_type": {"client_credentials"}}
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
-		"https://www.reddit.com/api/v1/access_token",
+		redditTokenURL,
 		strings.NewReader(data.Encode()))
 	if err != nil {
 		return err
@@ -84,8 +206,8 @@ func (r *Reddit) authenticate(ctx context.Context) error {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("reddit auth status %d", resp.StatusCode)
+	if err := checkStatus(resp); err != nil {
+		return fmt.Errorf("reddit auth: %w", err)
 	}
 
 	var tokenResp struct {
@@ -101,68 +223,121 @@ func (r *Reddit) authenticate(ctx context.Context) error {
 	return nil
 }
 
-func (r *Reddit) fetchSubreddit(ctx context.Context, subreddit string) ([]Item, error) {
-	reqURL := fmt.Sprintf("https://oauth.reddit.com/r/%s/hot.json?limit=50", subreddit)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+// fetchListing pages through a subreddit listing with the after cursor
+// until limit posts have been read or the listing ends.
+func (r *Reddit) fetchListing(ctx context.Context, subreddit, listing string, limit int) ([]redditPost, error) {
+	path, query, _ := strings.Cut(listing, "?")
+	params, err := url.ParseQuery(query)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid listing %q: %w", listing, err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+r.token)
-	req.Header.Set("User-Agent", "airadar/1.0")
+	var (
+		posts []redditPost
+		after string
+	)
 
-	resp, err := r.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("fetch r/%s: %w", subreddit, err)
+	for len(posts) < limit {
+		params.Set("limit", strconv.Itoa(min(100, limit-len(posts))))
+		params.Set("raw_json", "1")
+		if after != "" {
+			params.Set("after", after)
+		}
+
+		reqURL := fmt.Sprintf("%s/r/%s/%s.json?%s", redditAPIURL, subreddit, path, params.Encode())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Authorization", "Bearer "+r.token)
+		req.Header.Set("User-Agent", "airadar/1.0")
+
+		resp, err := r.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("fetch r/%s: %w", subreddit, err)
+		}
+
+		if err := checkStatus(resp); err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("reddit r/%s: %w", subreddit, err)
+		}
+
+		var page redditListing
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decode r/%s: %w", subreddit, err)
+		}
+
+		for _, child := range page.Data.Children {
+			posts = append(posts, child.Data)
+		}
+
+		after = page.Data.After
+		if after == "" || len(page.Data.Children) == 0 {
+			break
+		}
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("reddit r/%s status %d", subreddit, resp.StatusCode)
+	return posts, nil
+}
+
+// redditItem converts an (original, non-crosspost) post into an Item.
+func redditItem(post redditPost) *Item {
+	postURL := post.URL
+	if postURL == "" || strings.HasPrefix(postURL, "/r/") {
+		postURL = "https://reddit.com" + post.Permalink
 	}
 
-	var listing redditListing
-	if err := json.NewDecoder(resp.Body).Decode(&listing); err != nil {
-		return nil, fmt.Errorf("decode r/%s: %w", subreddit, err)
+	return &Item{
+		ID:          fmt.Sprintf("reddit:%s", post.ID),
+		Source:      SourceReddit,
+		ExternalID:  post.ID,
+		Title:       post.Title,
+		URL:         postURL,
+		Description: truncate(post.Selftext, 500),
+		Author:      post.Author,
+		Score:       post.Score,
+		Comments:    post.NumComments,
+		Tags:        []string{post.Subreddit},
+		PublishedAt: time.Unix(int64(post.CreatedUTC), 0).UTC(),
+		CollectedAt: time.Now().UTC(),
+		Extra: map[string]any{
+			"subreddit":      post.Subreddit,
+			"upvote_ratio":   post.UpvoteRatio,
+			"num_crossposts": post.NumCrossposts,
+		},
 	}
+}
 
-	var items []Item
-	for _, child := range listing.Data.Children {
-		post := child.Data
-		if post.Stickied {
-			continue
+// addSubreddit records another subreddit the post was seen in.
+func addSubreddit(item *Item, sub string) {
+	for _, t := range item.Tags {
+		if strings.EqualFold(t, sub) {
+			return
 		}
+	}
+	item.Tags = append(item.Tags, sub)
+	item.Extra["subreddits"] = item.Tags
+}
 
-		postURL := post.URL
-		if postURL == "" || strings.HasPrefix(postURL, "/r/") {
-			postURL = "https://reddit.com" + post.Permalink
+// addCrosspost links a crosspost ID to the original post's item.
+func addCrosspost(item *Item, id string) {
+	ids, _ := item.Extra["crosspost_ids"].([]string)
+	for _, existing := range ids {
+		if existing == id {
+			return
 		}
-
-		items = append(items, Item{
-			ID:          fmt.Sprintf("reddit:%s", post.ID),
-			Source:      SourceReddit,
-			ExternalID:  post.ID,
-			Title:       post.Title,
-			URL:         postURL,
-			Description: truncate(post.Selftext, 500),
-			Author:      post.Author,
-			Score:       post.Score,
-			Comments:    post.NumComments,
-			Tags:        []string{subreddit},
-			PublishedAt: time.Unix(int64(post.CreatedUTC), 0).UTC(),
-			CollectedAt: time.Now().UTC(),
-			Extra: map[string]any{
-				"subreddit": subreddit,
-				"upvote_ratio": post.UpvoteRatio,
-			},
-		})
 	}
-
-	return items, nil
+	ids = append(ids, id)
+	item.Extra["crosspost_ids"] = ids
+	item.Extra["crossposts_seen"] = len(ids)
 }
 
 type redditListing struct {
 	Data struct {
+		After    string `json:"after"`
 		Children []struct {
 			Data redditPost `json:"data"`
 		} `json:"children"`
@@ -170,15 +345,58 @@ type redditListing struct {
 }
 
 type redditPost struct {
-	ID          string  `json:"id"`
-	Title       string  `json:"title"`
-	URL         string  `json:"url"`
-	Permalink   string  `json:"permalink"`
-	Selftext    string  `json:"selftext"`
-	Author      string  `json:"author"`
-	Score       int     `json:"score"`
-	NumComments int     `json:"num_comments"`
-	CreatedUTC  float64 `json:"created_utc"`
-	Stickied    bool    `json:"stickied"`
-	UpvoteRatio float64 `json:"upvote_ratio"`
+	ID                  string       `json:"id"`
+	Title               string       `json:"title"`
+	URL                 string       `json:"url"`
+	Permalink           string       `json:"permalink"`
+	Selftext            string       `json:"selftext"`
+	Author              string       `json:"author"`
+	Subreddit           string       `json:"subreddit"`
+	Score               int          `json:"score"`
+	NumComments         int          `json:"num_comments"`
+	NumCrossposts       int          `json:"num_crossposts"`
+	CreatedUTC          float64      `json:"created_utc"`
+	Stickied            bool         `json:"stickied"`
+	UpvoteRatio         float64      `json:"upvote_ratio"`
+	CrosspostParent     string       `json:"crosspost_parent"`
+	CrosspostParentList []redditPost `json:"crosspost_parent_list"`
+}
+
+// RedditConfig for Reddit collector.
+type RedditConfig struct {
+	Enabled      bool     `yaml:"enabled"`
+	ClientID     string   `yaml:"client_id"`
+	ClientSecret string   `yaml:"client_secret"`
+	Subreddits   []string `yaml:"subreddits"`
+	Listings     []string `yaml:"listings"`    // hot, rising, new, top?t=day
+	MaxPerSub    int      `yaml:"max_per_sub"` // posts per subreddit and listing
+}
+
+func init() {
+	Register(Factory[RedditConfig]{
+		Type: SourceReddit,
+		Defaults: func() RedditConfig {
+			return RedditConfig{
+				Enabled: false,
+				Subreddits: []string{
+					"MachineLearning", "artificial", "LocalLLM",
+					"singularity", "ChatGPT", "StableDiffusion",
+				},
+				Listings:  []string{"hot"},
+				MaxPerSub: 50,
+			}
+		},
+		Env: func(c *RedditConfig) {
+			if v := os.Getenv("REDDIT_CLIENT_ID"); v != "" {
+				c.ClientID = v
+			}
+			if v := os.Getenv("REDDIT_CLIENT_SECRET"); v != "" {
+				c.ClientSecret = v
+			}
+		},
+		Enabled: func(c RedditConfig) bool { return c.Enabled },
+		New: func(c RedditConfig, deps Deps) ([]Source, error) {
+			return []Source{NewReddit(c.ClientID, c.ClientSecret, c.Subreddits, c.Listings, c.MaxPerSub)}, nil
+		},
+	})
 }
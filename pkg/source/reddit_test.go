@@ -0,0 +1,131 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeReddit serves a token and the top listing of each subreddit, paged
+// like Reddit's.
+type fakeReddit struct {
+	posts    map[string][]redditPost // by subreddit, in listing order
+	private  map[string]bool         // subreddits answering 403
+	listings []string                // requested listing paths
+}
+
+func (f *fakeReddit) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/token" {
+		fmt.Fprint(w, `{"access_token": "t", "expires_in": 3600}`)
+		return
+	}
+	f.listings = append(f.listings, r.URL.Path+"?t="+r.URL.Query().Get("t"))
+
+	sub := strings.Split(r.URL.Path, "/")[2]
+	if f.private[sub] {
+		http.Error(w, "private", http.StatusForbidden)
+		return
+	}
+	posts := f.posts[sub]
+	start := 0
+	if after := r.URL.Query().Get("after"); after != "" {
+		start, _ = strconv.Atoi(strings.TrimPrefix(after, "t3_"))
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	end := min(start+limit, len(posts), redditListingCap)
+
+	var page redditListing
+	for _, p := range posts[start:end] {
+		page.Data.Children = append(page.Data.Children, struct {
+			Data redditPost `json:"data"`
+		}{p})
+	}
+	if end < min(len(posts), redditListingCap) {
+		page.Data.After = "t3_" + strconv.Itoa(end)
+	}
+	json.NewEncoder(w).Encode(page)
+}
+
+func newTestReddit(t *testing.T, f *fakeReddit, subs ...string) *Reddit {
+	t.Helper()
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	tokenURL, apiURL := redditTokenURL, redditAPIURL
+	t.Cleanup(func() { redditTokenURL, redditAPIURL = tokenURL, apiURL })
+	redditTokenURL, redditAPIURL = srv.URL+"/token", srv.URL
+	return NewReddit("id", "secret", subs, nil, 0)
+}
+
+func redditPosts(sub string, n int, published time.Time) []redditPost {
+	posts := make([]redditPost, n)
+	for i := range posts {
+		posts[i] = redditPost{
+			ID: fmt.Sprintf("%s%d", sub, i), Subreddit: sub, Title: "post",
+			CreatedUTC: float64(published.Add(-time.Duration(i) * time.Minute).Unix()),
+		}
+	}
+	return posts
+}
+
+func TestRedditBackfill(t *testing.T) {
+	now := time.Now()
+	f := &fakeReddit{posts: map[string][]redditPost{
+		"quiet": redditPosts("quiet", 30, now.Add(-2*time.Hour)),
+		"busy":  redditPosts("busy", 1500, now.Add(-time.Hour)),
+	}}
+	r := newTestReddit(t, f, "quiet", "busy")
+
+	items, err := r.Backfill(context.Background(), now.Add(-3*24*time.Hour), now)
+	if err != nil {
+		t.Fatalf("Backfill: %v", err)
+	}
+	if len(items) != 30+redditListingCap {
+		t.Fatalf("backfilled %d posts, want all 30 quiet ones and the first %d busy ones", len(items), redditListingCap)
+	}
+	if f.listings[0] != "/r/quiet/top.json?t=week" {
+		t.Fatalf("first request = %s, want the weekly top listing", f.listings[0])
+	}
+
+	_, capped, _ := r.collect(context.Background(), []string{"top?t=week"}, redditListingCap, nil)
+	if len(capped) != 1 || capped[0] != "busy" {
+		t.Fatalf("capped = %v, want busy", capped)
+	}
+}
+
+func TestRedditBackfillRejectsOlderThanAYear(t *testing.T) {
+	f := &fakeReddit{}
+	r := newTestReddit(t, f, "MachineLearning")
+
+	from := time.Now().AddDate(-2, 0, 0)
+	_, err := r.Backfill(context.Background(), from, time.Now())
+	if err == nil || !strings.Contains(err.Error(), "reach back a year") {
+		t.Fatalf("Backfill = %v, want an error", err)
+	}
+	if len(f.listings) != 0 {
+		t.Fatalf("fetched %v for a range Reddit can't serve", f.listings)
+	}
+}
+
+func TestRedditCollectFailsOnlyWhenEverySubredditFails(t *testing.T) {
+	now := time.Now()
+	f := &fakeReddit{
+		posts:   map[string][]redditPost{"LocalLLaMA": redditPosts("LocalLLaMA", 3, now)},
+		private: map[string]bool{"secret": true, "hidden": true},
+	}
+
+	items, err := newTestReddit(t, f, "secret", "LocalLLaMA").Collect(context.Background())
+	if err != nil || len(items) != 3 {
+		t.Fatalf("Collect with one private subreddit = %d items, %v, want 3 items", len(items), err)
+	}
+
+	items, err = newTestReddit(t, f, "secret", "hidden").Collect(context.Background())
+	if err == nil {
+		t.Fatalf("Collect with every subreddit private = %d items, want an error", len(items))
+	}
+}
@@ -0,0 +1,398 @@
+package source
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StateStore is what sources persist between runs: imported feeds,
+// previously collected items, monitored page contents and cursors.
+type StateStore interface {
+	RSSStore
+	ArXivStore
+	PageStore
+	CursorStore
+}
+
+// Deps are the shared services handed to source constructors.
+type Deps struct {
+	Filter *Filter
+	Store  StateStore // nil when running without a database
+	Config Config     // every source's section, for settings shared between sources
+}
+
+// Factory describes a source type to the registry: the name of its config
+// section, its defaults and how to build it.
+//
+// Built-in sources register themselves from init functions. Programs
+// embedding airadar register their own before loading the config:
+//
+//	source.Register(source.Factory[MyConfig]{
+//		Type:     "mysource",
+//		Defaults: func() MyConfig { return MyConfig{Limit: 50} },
+//		Enabled:  func(c MyConfig) bool { return c.Enabled },
+//		New: func(c MyConfig, deps source.Deps) ([]source.Source, error) {
+//			return []source.Source{NewMySource(c, deps.Filter)}, nil
+//		},
+//	})
+type Factory[C any] struct {
+	// Type is the source type and the key of its section under "sources:".
+	Type SourceType
+	// Aliases are short names accepted wherever a source is selected by
+	// name, e.g. "hn" for hackernews.
+	Aliases []string
+	// Defaults returns the config used when the section is missing. Keys
+	// present in the file override single fields.
+	Defaults func() C
+	// Env applies environment variable overrides, such as API tokens.
+	Env func(c *C)
+	// Enabled reports whether the config turns the source on.
+	Enabled func(c C) bool
+	// New builds the source. Config-driven types (json_api, exec) may
+	// return several sources with types of their own.
+	New func(c C, deps Deps) ([]Source, error)
+}
+
+// Registration is the public description of a registered source type.
+type Registration struct {
+	Type    SourceType `json:"type"`
+	Aliases []string   `json:"aliases,omitempty"`
+}
+
+type registration struct {
+	Registration
+	defaults func() any // returns *C
+	list     bool       // C is a slice of declared sources
+	env      func(cfg any)
+	enabled  func(cfg any) bool
+	build    func(cfg any, deps Deps) ([]Source, error)
+}
+
+var registry = struct {
+	sync.RWMutex
+	list   []*registration
+	byName map[string]*registration // types and aliases
+}{byName: make(map[string]*registration)}
+
+// Register adds a source type to the registry. It panics when the type or
+// an alias is already taken, like database/sql.Register.
+func Register[C any](f Factory[C]) {
+	if f.Type == "" || f.New == nil {
+		panic("source: Register needs a Type and New")
+	}
+
+	r := &registration{
+		Registration: Registration{Type: f.Type, Aliases: f.Aliases},
+		list:         reflect.TypeFor[C]().Kind() == reflect.Slice,
+		defaults: func() any {
+			c := new(C)
+			if f.Defaults != nil {
+				*c = f.Defaults()
+			}
+			return c
+		},
+		env: func(cfg any) {
+			if f.Env != nil {
+				f.Env(cfg.(*C))
+			}
+		},
+		enabled: func(cfg any) bool {
+			return f.Enabled == nil || f.Enabled(*cfg.(*C))
+		},
+		build: func(cfg any, deps Deps) ([]Source, error) {
+			return f.New(*cfg.(*C), deps)
+		},
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	names := append([]string{string(f.Type)}, f.Aliases...)
+	for _, name := range names {
+		if _, dup := registry.byName[strings.ToLower(name)]; dup {
+			panic(fmt.Sprintf("source: %q registered twice", name))
+		}
+	}
+	for _, name := range names {
+		registry.byName[strings.ToLower(name)] = r
+	}
+	registry.list = append(registry.list, r)
+}
+
+// Registrations returns all registered source types, sorted by type.
+func Registrations() []Registration {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	regs := make([]Registration, len(registry.list))
+	for i, r := range registry.list {
+		regs[i] = r.Registration
+	}
+	sort.Slice(regs, func(i, j int) bool { return regs[i].Type < regs[j].Type })
+	return regs
+}
+
+// Lookup resolves a source type or alias to its registered type.
+func Lookup(name string) (SourceType, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	r, ok := registry.byName[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return "", false
+	}
+	return r.Type, true
+}
+
+// customType validates the name and type of a source declared in config
+// (json_api, exec) and returns its type, which defaults to the name. The
+// type must not resolve to a registered source, or its items would mix
+// with that source's.
+func customType(name, typ string) (SourceType, error) {
+	if strings.TrimSpace(name) == "" {
+		return "", errors.New("missing name")
+	}
+	if typ == "" {
+		typ = name
+	}
+	if t, ok := Lookup(typ); ok {
+		return "", fmt.Errorf("%s: type %q is taken by the %s source", name, typ, t)
+	}
+	return SourceType(typ), nil
+}
+
+// Select returns the sources matching any of names. A name is an instance
+// name, or a registered type or alias selecting all instances of the type.
+func Select(sources []Source, names []string) []Source {
+	wanted := make(map[string]bool)
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if t, ok := Lookup(name); ok {
+			wanted[string(t)] = true
+		}
+		wanted[name] = true
+	}
+
+	var selected []Source
+	for _, s := range sources {
+		inst := InstanceOf(s)
+		if wanted[strings.ToLower(inst.InstanceName())] || wanted[string(inst.Type)] || wanted[string(s.Name())] {
+			selected = append(selected, s)
+		}
+	}
+	return selected
+}
+
+func lookupType(t SourceType) *registration {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	r := registry.byName[strings.ToLower(string(t))]
+	if r == nil || r.Type != t {
+		return nil
+	}
+	return r
+}
+
+// Config holds the decoded config section of every registered source,
+// keyed by type. It decodes from the "sources:" mapping of the config file.
+type Config map[SourceType]*sourceSection
+
+// sourceSection is one source type's config: the section itself, or one
+// config per instance when the section is a list of them.
+type sourceSection struct {
+	config    any // *C
+	settings  instanceSettings
+	instances []sourceInstance
+}
+
+type sourceInstance struct {
+	config   any // *C
+	settings instanceSettings
+}
+
+// entries returns the configs to build: the instances if there are any,
+// otherwise the section.
+func (s *sourceSection) entries() []sourceInstance {
+	if len(s.instances) > 0 {
+		return s.instances
+	}
+	return []sourceInstance{{config: s.config, settings: s.settings}}
+}
+
+// DefaultConfig returns the defaults of every registered source.
+func DefaultConfig() Config {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	cfg := make(Config, len(registry.list))
+	for _, r := range registry.list {
+		cfg[r.Type] = &sourceSection{config: r.defaults()}
+	}
+	return cfg
+}
+
+// UnmarshalYAML decodes each section into its source's config, on top of
+// the defaults already present. Sections of unregistered types are ignored.
+//
+// Besides its own keys, a section may set name, interval, weight and filter.
+// A section written as a list defines named instances of the type instead,
+// each starting from the defaults; types whose config is itself a list
+// (json_api, exec) get one instance per entry.
+func (c *Config) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: sources must be a mapping", node.Line)
+	}
+	if *c == nil {
+		*c = DefaultConfig()
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+
+		r := lookupType(SourceType(key))
+		if r == nil {
+			continue
+		}
+		sec, ok := (*c)[r.Type]
+		if !ok {
+			sec = &sourceSection{config: r.defaults()}
+			(*c)[r.Type] = sec
+		}
+		if err := sec.decode(r, value); err != nil {
+			return fmt.Errorf("sources.%s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func (s *sourceSection) decode(r *registration, value *yaml.Node) error {
+	if value.Kind == yaml.SequenceNode && !r.list {
+		s.instances = nil
+		for i, n := range value.Content {
+			inst := sourceInstance{config: r.defaults()}
+			if err := n.Decode(inst.config); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+			if err := n.Decode(&inst.settings); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+			if inst.settings.Name == "" {
+				return fmt.Errorf("[%d]: instances need a name", i)
+			}
+			if err := inst.settings.validate(); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+			s.instances = append(s.instances, inst)
+		}
+		return nil
+	}
+
+	if err := value.Decode(s.config); err != nil {
+		return err
+	}
+	if value.Kind != yaml.MappingNode || r.list {
+		return nil
+	}
+	if err := value.Decode(&s.settings); err != nil {
+		return err
+	}
+	return s.settings.validate()
+}
+
+// ApplyEnv applies every source's environment variable overrides.
+func (c Config) ApplyEnv() {
+	for t, sec := range c {
+		r := lookupType(t)
+		if r == nil {
+			continue
+		}
+		r.env(sec.config)
+		for _, inst := range sec.instances {
+			r.env(inst.config)
+		}
+	}
+}
+
+// Enabled reports whether the config turns the source type, or any of its
+// instances, on.
+func (c Config) Enabled(t SourceType) bool {
+	r := lookupType(t)
+	sec, ok := c[t]
+	if r == nil || !ok {
+		return false
+	}
+	for _, inst := range sec.entries() {
+		if r.enabled(inst.config) {
+			return true
+		}
+	}
+	return false
+}
+
+// Section returns the config of source type t, without its instances.
+func Section[C any](c Config, t SourceType) (C, bool) {
+	var section *C
+	if sec, ok := c[t]; ok {
+		section, _ = sec.config.(*C)
+	}
+	if section == nil {
+		var zero C
+		return zero, false
+	}
+	return *section, true
+}
+
+// Build constructs every enabled source and instance in registration order,
+// wrapped as *Instance. Sources that fail to build or reuse an instance name
+// are reported in the returned error; the others are still returned.
+func Build(cfg Config, deps Deps) ([]Source, error) {
+	registry.RLock()
+	list := append([]*registration(nil), registry.list...)
+	registry.RUnlock()
+
+	deps.Config = cfg
+
+	var (
+		sources []Source
+		errs    []error
+		names   = make(map[string]bool)
+	)
+	for _, r := range list {
+		sec, ok := cfg[r.Type]
+		if !ok {
+			sec = &sourceSection{config: r.defaults()}
+		}
+		for _, entry := range sec.entries() {
+			if !r.enabled(entry.config) {
+				continue
+			}
+			d := deps
+			d.Filter = entry.settings.filter(deps.Filter)
+
+			built, err := r.build(entry.config, d)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", cmp.Or(entry.settings.Name, string(r.Type)), err))
+			}
+			for _, src := range built {
+				inst := entry.settings.instance(src, r.Type)
+				key := strings.ToLower(inst.InstanceName())
+				if names[key] {
+					errs = append(errs, fmt.Errorf("%s: source instance %q defined twice", r.Type, inst.InstanceName()))
+					continue
+				}
+				names[key] = true
+				inst.attach(deps.Store)
+				sources = append(sources, inst)
+			}
+		}
+	}
+	return sources, errors.Join(errs...)
+}
@@ -0,0 +1,184 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const sourceProbe SourceType = "registry_probe"
+
+type probeConfig struct {
+	Enabled bool     `yaml:"enabled"`
+	Limit   int      `yaml:"limit"`
+	Tags    []string `yaml:"tags"`
+	Token   string   `yaml:"token"`
+	Fail    bool     `yaml:"fail"`
+}
+
+type probeSource struct{ cfg probeConfig }
+
+func (p *probeSource) Name() SourceType                        { return sourceProbe }
+func (p *probeSource) Collect(context.Context) ([]Item, error) { return nil, nil }
+
+func init() {
+	Register(Factory[probeConfig]{
+		Type:     sourceProbe,
+		Aliases:  []string{"probe"},
+		Defaults: func() probeConfig { return probeConfig{Limit: 10, Tags: []string{"default"}} },
+		Env:      func(c *probeConfig) { c.Token = "from-env" },
+		Enabled:  func(c probeConfig) bool { return c.Enabled },
+		New: func(c probeConfig, deps Deps) ([]Source, error) {
+			if c.Fail {
+				return nil, errors.New("probe failed")
+			}
+			return []Source{&probeSource{cfg: c}}, nil
+		},
+	})
+}
+
+// probes returns the probe instances among sources.
+func probes(sources []Source) []*Instance {
+	var out []*Instance
+	for _, s := range sources {
+		if inst := InstanceOf(s); inst.Type == sourceProbe {
+			out = append(out, inst)
+		}
+	}
+	return out
+}
+
+func decodeSources(t *testing.T, doc string) Config {
+	t.Helper()
+	var cfg Config
+	if err := yaml.Unmarshal([]byte(doc), &cfg); err != nil {
+		t.Fatalf("decode config: %v", err)
+	}
+	return cfg
+}
+
+func TestConfigUnmarshalYAML(t *testing.T) {
+	t.Run("keys override single defaults", func(t *testing.T) {
+		cfg := decodeSources(t, "registry_probe:\n  enabled: true\n  limit: 3")
+		c, ok := Section[probeConfig](cfg, sourceProbe)
+		if !ok {
+			t.Fatal("probe section missing")
+		}
+		if !c.Enabled || c.Limit != 3 || len(c.Tags) != 1 || c.Tags[0] != "default" {
+			t.Fatalf("section = %+v, want limit 3 and default tags", c)
+		}
+	})
+
+	t.Run("missing sections keep their defaults", func(t *testing.T) {
+		cfg := decodeSources(t, "hackernews:\n  limit: 5")
+		c, ok := Section[probeConfig](cfg, sourceProbe)
+		if !ok || c.Enabled || c.Limit != 10 {
+			t.Fatalf("section = %+v, %v, want defaults", c, ok)
+		}
+		if hn, _ := Section[HackerNewsConfig](cfg, SourceHackerNews); !hn.Enabled || hn.Limit != 5 {
+			t.Fatalf("hackernews = %+v, want enabled by default with limit 5", hn)
+		}
+	})
+
+	t.Run("unknown sections are ignored", func(t *testing.T) {
+		cfg := decodeSources(t, "no_such_source:\n  enabled: true\nregistry_probe:\n  enabled: true")
+		if _, ok := cfg["no_such_source"]; ok {
+			t.Fatal("unknown section decoded")
+		}
+		if !cfg.Enabled(sourceProbe) {
+			t.Fatal("probe not enabled")
+		}
+	})
+
+	t.Run("env overrides", func(t *testing.T) {
+		cfg := decodeSources(t, "registry_probe:\n  token: from-file")
+		cfg.ApplyEnv()
+		if c, _ := Section[probeConfig](cfg, sourceProbe); c.Token != "from-env" {
+			t.Fatalf("token = %q, want from-env", c.Token)
+		}
+	})
+
+	errTests := []struct {
+		name, doc, wantErr string
+	}{
+		{"not a mapping", "- registry_probe", "sources must be a mapping"},
+		{"bad field", "registry_probe:\n  limit: lots", "sources.registry_probe"},
+		{"bad interval", "registry_probe:\n  interval: often", `invalid interval "often"`},
+		{"negative weight", "registry_probe:\n  weight: -1", "negative weight"},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg Config
+			err := yaml.Unmarshal([]byte(tt.doc), &cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("decode = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	t.Run("disabled sources aren't built", func(t *testing.T) {
+		sources, err := buildFromYAML(t, "registry_probe:\n  limit: 3")
+		if err != nil {
+			t.Fatalf("Build: %v", err)
+		}
+		if got := probes(sources); len(got) != 0 {
+			t.Fatalf("built %d disabled probes", len(got))
+		}
+	})
+
+	t.Run("section settings", func(t *testing.T) {
+		sources, err := buildFromYAML(t, "registry_probe:\n  enabled: true\n  limit: 3\n  interval: 2h\n  weight: 1.5")
+		if err != nil {
+			t.Fatalf("Build: %v", err)
+		}
+		got := probes(sources)
+		if len(got) != 1 {
+			t.Fatalf("built %d probes, want 1", len(got))
+		}
+		inst := got[0]
+		if inst.InstanceName() != string(sourceProbe) || inst.Interval.Hours() != 2 || inst.Weight != 1.5 {
+			t.Fatalf("instance = %s every %s weight %v", inst.InstanceName(), inst.Interval, inst.Weight)
+		}
+		if limit := inst.Source.(*probeSource).cfg.Limit; limit != 3 {
+			t.Fatalf("limit = %d, want 3", limit)
+		}
+	})
+
+	t.Run("failures don't drop other sources", func(t *testing.T) {
+		sources, err := buildFromYAML(t, "registry_probe:\n  enabled: true\n  fail: true\nhackernews:\n  enabled: true")
+		if err == nil || !strings.Contains(err.Error(), "registry_probe: probe failed") {
+			t.Fatalf("Build error = %v, want the probe failure", err)
+		}
+		if len(probes(sources)) != 0 || len(Select(sources, []string{"hn"})) != 1 {
+			t.Fatalf("built %d sources, want hackernews only", len(sources))
+		}
+	})
+
+	t.Run("select by alias", func(t *testing.T) {
+		sources, err := buildFromYAML(t, "registry_probe:\n  enabled: true")
+		if err != nil {
+			t.Fatalf("Build: %v", err)
+		}
+		if got := Select(sources, []string{"Probe"}); len(got) != 1 || InstanceOf(got[0]).Type != sourceProbe {
+			t.Fatalf("Select(Probe) = %d sources", len(got))
+		}
+	})
+}
+
+func TestRegisterTwicePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("registering a taken alias didn't panic")
+		}
+	}()
+	Register(Factory[probeConfig]{
+		Type:    "registry_probe_2",
+		Aliases: []string{"PROBE"},
+		New:     func(probeConfig, Deps) ([]Source, error) { return nil, nil },
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/mmcdole/gofeed"
@@ -11,43 +12,108 @@ import (
 
 // RSSFeed is a named RSS/Atom feed URL.
 type RSSFeed struct {
-	Name string
-	URL  string
+	Name string `db:"name" yaml:"name"`
+	URL  string `db:"url" yaml:"url"`
+}
+
+// RSSStore persists feeds imported from OPML, per RSS instance.
+type RSSStore interface {
+	ListFeeds(ctx context.Context, instance string) ([]RSSFeed, error)
 }
 
 // RSS collects AI news from RSS/Atom feeds.
+//
+// Per feed, its cursors keep the HTTP validators (ETag / Last-Modified) for
+// conditional requests and the newest entry seen, so a run only emits
+// entries published since the last one. The first run takes the last 24h.
 type RSS struct {
-	client *http.Client
-	parser *gofeed.Parser
-	feeds  []RSSFeed
-	filter *Filter
+	client   *http.Client
+	feeds    []RSSFeed
+	filter   *Filter
+	store    RSSStore // optional
+	instance string   // whose imported feeds to read
+	workers  int
+	cursors  *Cursors
 }
 
-// NewRSS creates a new RSS collector.
-func NewRSS(feeds []RSSFeed, filter *Filter) *RSS {
+// NewRSS creates a new RSS collector. store may be nil, in which case
+// imported feeds are ignored.
+func NewRSS(feeds []RSSFeed, filter *Filter, store RSSStore, workers int) *RSS {
+	if workers <= 0 {
+		workers = 4
+	}
 	return &RSS{
-		client: &http.Client{Timeout: 30 * time.Second},
-		parser: gofeed.NewParser(),
-		feeds:  feeds,
-		filter: filter,
+		client:   newHTTPClient(30 * time.Second),
+		feeds:    feeds,
+		filter:   filter,
+		store:    store,
+		instance: string(SourceRSS),
+		workers:  workers,
+		cursors:  newCursors(nil, ""),
 	}
 }
 
 func (r *RSS) Name() SourceType { return SourceRSS }
 
+func (r *RSS) setInstance(name string) { r.instance = name }
+
+func (r *RSS) setCursors(c *Cursors) { r.cursors = c }
+
 func (r *RSS) Collect(ctx context.Context) ([]Item, error) {
-	var allItems []Item
+	feeds := r.allFeeds(ctx)
+
+	var (
+		mu       sync.Mutex
+		allItems []Item
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, r.workers)
+	)
+
+	for _, feed := range feeds {
+		wg.Add(1)
+		go func(feed RSSFeed) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			items, err := r.collectFeed(ctx, feed)
+			if err != nil {
+				fmt.Printf("  rss feed %s error: %v\n", feed.Name, err)
+				return
+			}
+
+			mu.Lock()
+			allItems = append(allItems, items...)
+			mu.Unlock()
+		}(feed)
+	}
 
-	for _, feed := range r.feeds {
-		items, err := r.collectFeed(ctx, feed)
+	wg.Wait()
+	return allItems, nil
+}
+
+// allFeeds merges configured feeds with the feeds imported into the store
+// for this instance, dropping duplicate URLs.
+func (r *RSS) allFeeds(ctx context.Context) []RSSFeed {
+	feeds := r.feeds
+	if r.store != nil {
+		imported, err := r.store.ListFeeds(ctx, r.instance)
 		if err != nil {
-			fmt.Printf("  rss feed %s error: %v\n", feed.Name, err)
-			continue
+			fmt.Printf("  rss imported feeds error: %v\n", err)
 		}
-		allItems = append(allItems, items...)
+		feeds = append(feeds[:len(feeds):len(feeds)], imported...)
 	}
 
-	return allItems, nil
+	seen := make(map[string]bool)
+	var out []RSSFeed
+	for _, f := range feeds {
+		if f.URL == "" || seen[f.URL] {
+			continue
+		}
+		seen[f.URL] = true
+		out = append(out, f)
+	}
+	return out
 }
 
 func (r *RSS) collectFeed(ctx context.Context, feed RSSFeed) ([]Item, error) {
@@ -57,36 +123,73 @@ func (r *RSS) collectFeed(ctx context.Context, feed RSSFeed) ([]Item, error) {
 	}
 	req.Header.Set("User-Agent", "airadar/1.0")
 
+	etag, err := r.cursors.Get(ctx, "etag:"+feed.URL)
+	if err != nil {
+		fmt.Printf("  rss feed %s cursor error: %v\n", feed.Name, err)
+	}
+	lastModified, err := r.cursors.Get(ctx, "last_modified:"+feed.URL)
+	if err != nil {
+		fmt.Printf("  rss feed %s cursor error: %v\n", feed.Name, err)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
 	resp, err := r.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("fetch rss %s: %w", feed.Name, err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("rss %s status %d", feed.Name, resp.StatusCode)
+	// Unchanged since the last fetch: nothing new to parse.
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, nil
 	}
 
-	parsed, err := r.parser.Parse(resp.Body)
+	if err := checkStatus(resp); err != nil {
+		return nil, fmt.Errorf("rss %s: %w", feed.Name, err)
+	}
+
+	// gofeed parsers keep per-parse state, so each goroutine needs its own.
+	parsed, err := gofeed.NewParser().Parse(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("parse rss %s: %w", feed.Name, err)
 	}
 
-	var items []Item
-	cutoff := time.Now().Add(-24 * time.Hour) // Only last 24h
+	cursorKey := "published:" + feed.URL
+	cutoff, err := r.cursors.Time(ctx, cursorKey)
+	if err != nil {
+		return nil, fmt.Errorf("rss %s cursor: %w", feed.Name, err)
+	}
+	if cutoff.IsZero() {
+		cutoff = time.Now().Add(-24 * time.Hour)
+	}
 
+	var (
+		items  []Item
+		newest time.Time
+	)
 	for _, entry := range parsed.Items {
 		published := time.Now().UTC()
+		dated := true
 		if entry.PublishedParsed != nil {
 			published = entry.PublishedParsed.UTC()
 		} else if entry.UpdatedParsed != nil {
 			published = entry.UpdatedParsed.UTC()
+		} else {
+			dated = false
 		}
 
-		// Skip old items.
-		if published.Before(cutoff) {
+		// Skip entries older than the cursor.
+		if !published.After(cutoff) {
 			continue
 		}
+		if dated && published.After(newest) {
+			newest = published
+		}
 
 		// Some RSS feeds are AI-specific (TechCrunch AI), others need filtering.
 		text := entry.Title + " " + entry.Description
@@ -122,6 +225,17 @@ func (r *RSS) collectFeed(ctx context.Context, feed RSSFeed) ([]Item, error) {
 		})
 	}
 
+	// Only advance the cursors once the body was parsed successfully.
+	if err := r.cursors.Set(ctx, "etag:"+feed.URL, resp.Header.Get("ETag")); err != nil {
+		fmt.Printf("  rss feed %s cursor error: %v\n", feed.Name, err)
+	}
+	if err := r.cursors.Set(ctx, "last_modified:"+feed.URL, resp.Header.Get("Last-Modified")); err != nil {
+		fmt.Printf("  rss feed %s cursor error: %v\n", feed.Name, err)
+	}
+	if err := r.cursors.Advance(ctx, cursorKey, newest); err != nil {
+		fmt.Printf("  rss feed %s cursor error: %v\n", feed.Name, err)
+	}
+
 	return items, nil
 }
 
@@ -131,3 +245,41 @@ func truncate(s string, maxLen int) string {
 	}
 	return s[:maxLen] + "..."
 }
+
+// RSSConfig for RSS feed collector.
+type RSSConfig struct {
+	Enabled bool      `yaml:"enabled"`
+	Feeds   []RSSFeed `yaml:"feeds"`
+	OPML    string    `yaml:"opml"`    // path to an OPML file with additional feeds
+	Workers int       `yaml:"workers"` // concurrent feed fetches
+}
+
+func init() {
+	Register(Factory[RSSConfig]{
+		Type: SourceRSS,
+		Defaults: func() RSSConfig {
+			return RSSConfig{
+				Enabled: true,
+				Workers: 4,
+				Feeds: []RSSFeed{
+					{Name: "TechCrunch AI", URL: "https://techcrunch.com/category/artificial-intelligence/feed/"},
+					{Name: "The Verge AI", URL: "https://www.theverge.com/rss/ai-artificial-intelligence/index.xml"},
+					{Name: "Ars Technica", URL: "https://feeds.arstechnica.com/arstechnica/technology-lab"},
+					{Name: "VentureBeat AI", URL: "https://venturebeat.com/category/ai/feed/"},
+				},
+			}
+		},
+		Enabled: func(c RSSConfig) bool { return c.Enabled },
+		New: func(c RSSConfig, deps Deps) ([]Source, error) {
+			feeds := c.Feeds
+			var err error
+			if c.OPML != "" {
+				var opmlFeeds []RSSFeed
+				// An unreadable OPML file still leaves the configured feeds.
+				opmlFeeds, err = LoadOPML(c.OPML)
+				feeds = append(feeds[:len(feeds):len(feeds)], opmlFeeds...)
+			}
+			return []Source{NewRSS(feeds, deps.Filter, deps.Store, c.Workers)}, err
+		},
+	})
+}
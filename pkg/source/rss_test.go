@@ -0,0 +1,58 @@
+package source
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+// feedStore serves imported feeds by instance.
+type feedStore map[string][]RSSFeed
+
+func (s feedStore) ListFeeds(_ context.Context, instance string) ([]RSSFeed, error) {
+	return s[instance], nil
+}
+
+func TestRSSImportedFeedsPerInstance(t *testing.T) {
+	store := feedStore{
+		"research": {{Name: "BAIR", URL: "https://bair.berkeley.edu/blog/feed.xml"}, {Name: "Dup", URL: "https://openai.com/blog/rss.xml"}},
+		"press":    {{Name: "Verge AI", URL: "https://www.theverge.com/ai/rss"}},
+	}
+	configured := []RSSFeed{{Name: "OpenAI", URL: "https://openai.com/blog/rss.xml"}}
+
+	tests := []struct {
+		instance string
+		want     []string
+	}{
+		{"research", []string{"OpenAI", "BAIR"}},
+		{"press", []string{"OpenAI", "Verge AI"}},
+		{"rss", []string{"OpenAI"}},
+	}
+	for _, tt := range tests {
+		r := NewRSS(configured, nil, store, 0)
+		if tt.instance != "rss" {
+			r.setInstance(tt.instance)
+		}
+		var names []string
+		for _, f := range r.allFeeds(context.Background()) {
+			names = append(names, f.Name)
+		}
+		if !slices.Equal(names, tt.want) {
+			t.Errorf("%s feeds = %v, want %v", tt.instance, names, tt.want)
+		}
+	}
+}
+
+func TestBuildHandsRSSItsInstance(t *testing.T) {
+	sources, err := buildFromYAML(t, "rss:\n  - {name: research, enabled: true}\n  - {name: press, enabled: true}")
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	var got []string
+	for _, src := range Select(sources, []string{"rss"}) {
+		got = append(got, InstanceOf(src).Source.(*RSS).instance)
+	}
+	if !slices.Equal(got, []string{"research", "press"}) {
+		t.Fatalf("rss instances = %v", got)
+	}
+}
@@ -9,19 +9,31 @@ import (
 type SourceType string
 
 const (
-	SourceHackerNews SourceType = "hackernews"
-	SourceGitHub     SourceType = "github"
-	SourceReddit     SourceType = "reddit"
-	SourceArXiv      SourceType = "arxiv"
-	SourceTwitter    SourceType = "twitter"
-	SourceYouTube    SourceType = "youtube"
-	SourceRSS        SourceType = "rss"
+	SourceHackerNews  SourceType = "hackernews"
+	SourceGitHub      SourceType = "github"
+	SourceReddit      SourceType = "reddit"
+	SourceArXiv       SourceType = "arxiv"
+	SourceTwitter     SourceType = "twitter"
+	SourceYouTube     SourceType = "youtube"
+	SourceRSS         SourceType = "rss"
+	SourceHuggingFace SourceType = "huggingface"
+	SourceBluesky     SourceType = "bluesky"
+	SourceMastodon    SourceType = "mastodon"
+	SourceProductHunt SourceType = "producthunt"
+	SourceHFPapers    SourceType = "hfpapers"
+	SourceLobsters    SourceType = "lobsters"
+	SourceDevTo       SourceType = "devto"
+	SourceOpenReview  SourceType = "openreview"
+	SourcePackages    SourceType = "packages"
+	SourceWebWatch    SourceType = "webwatch"
+	SourceNewsletter  SourceType = "newsletter"
 )
 
 // Item is the standardized data model for all sources.
 type Item struct {
 	ID          string         `json:"id" db:"id"`
 	Source      SourceType     `json:"source" db:"source"`
+	Instance    string         `json:"instance,omitempty" db:"instance"`
 	ExternalID  string         `json:"external_id" db:"external_id"`
 	Title       string         `json:"title" db:"title"`
 	URL         string         `json:"url" db:"url"`
@@ -33,6 +45,7 @@ type Item struct {
 	PublishedAt time.Time      `json:"published_at" db:"published_at"`
 	CollectedAt time.Time      `json:"collected_at" db:"collected_at"`
 	Extra       map[string]any `json:"extra,omitempty" db:"-"`
+	History     []Snapshot     `json:"-" db:"-"` // past scores, set by backfills
 	TagsJSON    string         `json:"-" db:"tags"`
 	ExtraJSON   string         `json:"-" db:"extra"`
 }
@@ -43,15 +56,28 @@ type Source interface {
 	Collect(ctx context.Context) ([]Item, error)
 }
 
-// AllSourceTypes returns all known source types.
+// Committer is implemented by sources that keep track of what they have
+// collected, e.g. by marking newsletters as processed. Commit is called once
+// the items of the last Collect are stored; when storing fails it isn't, and
+// the next Collect returns the items again.
+type Committer interface {
+	Commit(ctx context.Context) error
+}
+
+// Commit commits the state of src's last Collect, if it keeps any.
+func Commit(ctx context.Context, src Source) error {
+	if c, ok := src.(Committer); ok {
+		return c.Commit(ctx)
+	}
+	return nil
+}
+
+// AllSourceTypes returns all registered source types.
 func AllSourceTypes() []SourceType {
-	return []SourceType{
-		SourceHackerNews,
-		SourceGitHub,
-		SourceReddit,
-		SourceArXiv,
-		SourceTwitter,
-		SourceYouTube,
-		SourceRSS,
+	regs := Registrations()
+	types := make([]SourceType, len(regs))
+	for i, r := range regs {
+		types[i] = r.Type
 	}
+	return types
 }
@@ -10,12 +10,14 @@ import (
 	"github.com/mmcdole/gofeed"
 )
 
-// Twitter collects AI tweets via Nitter RSS feeds.
+// Twitter collects AI tweets via Nitter RSS feeds. Its cursors keep the
+// newest tweet seen per account; the first run takes the last 24h.
 type Twitter struct {
 	client    *http.Client
 	parser    *gofeed.Parser
 	nitterURL string
 	accounts  []string
+	cursors   *Cursors
 }
 
 // NewTwitter creates a new Twitter/X collector using Nitter RSS.
@@ -24,15 +26,18 @@ func NewTwitter(nitterURL string, accounts []string) *Twitter {
 		nitterURL = "https://nitter.net"
 	}
 	return &Twitter{
-		client:    &http.Client{Timeout: 30 * time.Second},
+		client:    newHTTPClient(30 * time.Second),
 		parser:    gofeed.NewParser(),
 		nitterURL: strings.TrimRight(nitterURL, "/"),
 		accounts:  accounts,
+		cursors:   newCursors(nil, ""),
 	}
 }
 
 func (t *Twitter) Name() SourceType { return SourceTwitter }
 
+func (t *Twitter) setCursors(c *Cursors) { t.cursors = c }
+
 func (t *Twitter) Collect(ctx context.Context) ([]Item, error) {
 	var allItems []Item
 
@@ -62,8 +67,8 @@ func (t *Twitter) collectAccount(ctx context.Context, account string) ([]Item, e
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("twitter @%s status %d", account, resp.StatusCode)
+	if err := checkStatus(resp); err != nil {
+		return nil, fmt.Errorf("twitter @%s: %w", account, err)
 	}
 
 	feed, err := t.parser.Parse(resp.Body)
@@ -71,16 +76,29 @@ func (t *Twitter) collectAccount(ctx context.Context, account string) ([]Item, e
 		return nil, fmt.Errorf("parse twitter @%s: %w", account, err)
 	}
 
-	var items []Item
-	cutoff := time.Now().Add(-24 * time.Hour)
+	cursorKey := "published:" + account
+	cutoff, err := t.cursors.Time(ctx, cursorKey)
+	if err != nil {
+		return nil, fmt.Errorf("twitter @%s cursor: %w", account, err)
+	}
+	if cutoff.IsZero() {
+		cutoff = time.Now().Add(-24 * time.Hour)
+	}
 
+	var (
+		items  []Item
+		newest time.Time
+	)
 	for _, entry := range feed.Items {
 		published := time.Now().UTC()
 		if entry.PublishedParsed != nil {
 			published = entry.PublishedParsed.UTC()
+			if published.After(newest) {
+				newest = published
+			}
 		}
 
-		if published.Before(cutoff) {
+		if !published.After(cutoff) {
 			continue
 		}
 
@@ -105,5 +123,28 @@ func (t *Twitter) collectAccount(ctx context.Context, account string) ([]Item, e
 		})
 	}
 
+	if err := t.cursors.Advance(ctx, cursorKey, newest); err != nil {
+		fmt.Printf("  twitter @%s cursor error: %v\n", account, err)
+	}
 	return items, nil
 }
+
+// TwitterConfig for Twitter/X collector.
+type TwitterConfig struct {
+	Enabled   bool     `yaml:"enabled"`
+	NitterURL string   `yaml:"nitter_url"`
+	Accounts  []string `yaml:"accounts"`
+}
+
+func init() {
+	Register(Factory[TwitterConfig]{
+		Type: SourceTwitter,
+		Defaults: func() TwitterConfig {
+			return TwitterConfig{Enabled: false, NitterURL: "https://nitter.net"}
+		},
+		Enabled: func(c TwitterConfig) bool { return c.Enabled },
+		New: func(c TwitterConfig, deps Deps) ([]Source, error) {
+			return []Source{NewTwitter(c.NitterURL, c.Accounts)}, nil
+		},
+	})
+}
@@ -0,0 +1,324 @@
+package source
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// WebPage is a page section to monitor for changes.
+type WebPage struct {
+	Name     string   `yaml:"name"`
+	URL      string   `yaml:"url"`
+	Selector string   `yaml:"selector"` // CSS selector of the monitored section (default: "body")
+	Ignore   []string `yaml:"ignore"`   // regexps; matching lines (dates, counters) are not compared
+}
+
+// PageStore persists the last seen content of each monitored section so a
+// restart doesn't report old changes again.
+type PageStore interface {
+	GetPageState(ctx context.Context, key string) (hash, content string, err error)
+	SetPageState(ctx context.Context, key, hash, content string) error
+}
+
+// WebWatch monitors pages that have no feed (changelogs, pricing and model
+// pages) and emits an item whenever the selected section's text changes. The
+// first check of a page only records a baseline.
+type WebWatch struct {
+	client *http.Client
+	pages  []WebPage
+	ignore [][]*regexp.Regexp
+	store  PageStore
+	memory map[string]pageState // used when store is nil
+}
+
+type pageState struct {
+	hash    string
+	content string
+}
+
+// NewWebWatch creates a new page change monitor. Pages with invalid ignore
+// patterns are reported and monitored without them.
+func NewWebWatch(pages []WebPage, store PageStore) *WebWatch {
+	w := &WebWatch{
+		client: newHTTPClient(30 * time.Second),
+		pages:  pages,
+		ignore: make([][]*regexp.Regexp, len(pages)),
+		store:  store,
+		memory: make(map[string]pageState),
+	}
+	for i, page := range pages {
+		for _, pattern := range page.Ignore {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				fmt.Printf("  webwatch %s: invalid ignore pattern %q: %v\n", page.Name, pattern, err)
+				continue
+			}
+			w.ignore[i] = append(w.ignore[i], re)
+		}
+	}
+	return w
+}
+
+func (w *WebWatch) Name() SourceType { return SourceWebWatch }
+
+func (w *WebWatch) Collect(ctx context.Context) ([]Item, error) {
+	var (
+		items   []Item
+		lastErr error
+		ok      int
+	)
+
+	for i, page := range w.pages {
+		item, err := w.check(ctx, page, w.ignore[i])
+		if err != nil {
+			fmt.Printf("  webwatch %s error: %v\n", page.Name, err)
+			lastErr = err
+			continue
+		}
+		ok++
+		if item != nil {
+			items = append(items, *item)
+		}
+	}
+
+	if ok == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return items, nil
+}
+
+// check fetches a page and compares the monitored section with the stored
+// copy. It returns nil when nothing changed.
+func (w *WebWatch) check(ctx context.Context, page WebPage, ignore []*regexp.Regexp) (*Item, error) {
+	selector := page.Selector
+	if selector == "" {
+		selector = "body"
+	}
+
+	content, err := w.fetchSection(ctx, page.URL, selector, ignore)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256([]byte(content))
+	hash := hex.EncodeToString(sum[:])
+	key := page.URL + "#" + selector
+
+	prevHash, prevContent, err := w.getState(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if hash == prevHash {
+		return nil, nil
+	}
+	if err := w.setState(ctx, key, hash, content); err != nil {
+		return nil, err
+	}
+	if prevHash == "" {
+		return nil, nil // baseline
+	}
+
+	added, removed := diffLines(prevContent, content)
+	if len(added) == 0 && len(removed) == 0 {
+		return nil, nil // only reordered
+	}
+
+	headline := "content removed"
+	if len(added) > 0 {
+		headline = added[0]
+	}
+
+	var desc strings.Builder
+	for _, l := range added {
+		desc.WriteString("+ " + l + "\n")
+	}
+	for _, l := range removed {
+		desc.WriteString("- " + l + "\n")
+	}
+
+	// A page can change back to an earlier version, so the ID combines the
+	// page and its new content.
+	keySum := sha256.Sum256([]byte(key))
+	externalID := hex.EncodeToString(keySum[:6]) + ":" + hash[:12]
+
+	now := time.Now().UTC()
+	return &Item{
+		ID:          fmt.Sprintf("webwatch:%s", externalID),
+		Source:      SourceWebWatch,
+		ExternalID:  externalID,
+		Title:       truncate(page.Name+": "+headline, 200),
+		URL:         page.URL,
+		Description: truncate(strings.TrimSpace(desc.String()), 500),
+		Author:      page.Name,
+		PublishedAt: now,
+		CollectedAt: now,
+		Extra: map[string]any{
+			"page":          page.Name,
+			"selector":      selector,
+			"added":         firstN(added, 20),
+			"removed":       firstN(removed, 20),
+			"added_lines":   len(added),
+			"removed_lines": len(removed),
+			"hash":          hash,
+			"previous_hash": prevHash,
+		},
+	}, nil
+}
+
+func (w *WebWatch) fetchSection(ctx context.Context, pageURL, selector string, ignore []*regexp.Regexp) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("create webwatch request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("User-Agent", "airadar/1.0")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetch page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return "", fmt.Errorf("page: %w", err)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("parse page: %w", err)
+	}
+
+	sel := doc.Find(selector)
+	if sel.Length() == 0 {
+		// Don't record an empty section as a change; the layout moved.
+		return "", fmt.Errorf("selector %q matched nothing", selector)
+	}
+
+	var b strings.Builder
+	sel.Each(func(_ int, s *goquery.Selection) {
+		blockText(s, &b)
+		b.WriteString("\n")
+	})
+
+	var lines []string
+	for _, line := range strings.Split(b.String(), "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" || matchesAny(ignore, line) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+var blockElements = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "header": true,
+	"footer": true, "li": true, "ul": true, "ol": true, "table": true,
+	"tr": true, "td": true, "th": true, "h1": true, "h2": true, "h3": true,
+	"h4": true, "h5": true, "h6": true, "pre": true, "blockquote": true,
+	"br": true, "hr": true, "dt": true, "dd": true, "main": true, "nav": true,
+}
+
+// blockText writes the text of s with a line break around every block
+// element, so each paragraph, list entry or table row becomes one line.
+func blockText(s *goquery.Selection, b *strings.Builder) {
+	s.Contents().Each(func(_ int, c *goquery.Selection) {
+		switch name := goquery.NodeName(c); {
+		case name == "#text":
+			b.WriteString(c.Text())
+		case name == "script" || name == "style" || name == "noscript" || name == "template":
+		case blockElements[name]:
+			b.WriteString("\n")
+			blockText(c, b)
+			b.WriteString("\n")
+		default:
+			blockText(c, b)
+		}
+	})
+}
+
+// diffLines returns the lines only in next and the lines only in prev,
+// counting duplicates.
+func diffLines(prev, next string) (added, removed []string) {
+	count := make(map[string]int)
+	for _, l := range strings.Split(prev, "\n") {
+		count[l]++
+	}
+	for _, l := range strings.Split(next, "\n") {
+		if count[l] > 0 {
+			count[l]--
+			continue
+		}
+		added = append(added, l)
+	}
+
+	remaining := make(map[string]int)
+	for l, n := range count {
+		remaining[l] = n
+	}
+	// Keep removed lines in page order.
+	for _, l := range strings.Split(prev, "\n") {
+		if remaining[l] > 0 {
+			remaining[l]--
+			removed = append(removed, l)
+		}
+	}
+	return added, removed
+}
+
+func matchesAny(res []*regexp.Regexp, s string) bool {
+	for _, re := range res {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+func firstN(s []string, n int) []string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+func (w *WebWatch) getState(ctx context.Context, key string) (string, string, error) {
+	if w.store == nil {
+		st := w.memory[key]
+		return st.hash, st.content, nil
+	}
+	return w.store.GetPageState(ctx, key)
+}
+
+func (w *WebWatch) setState(ctx context.Context, key, hash, content string) error {
+	if w.store == nil {
+		w.memory[key] = pageState{hash: hash, content: content}
+		return nil
+	}
+	return w.store.SetPageState(ctx, key, hash, content)
+}
+
+// WebWatchConfig for the web page change monitor.
+type WebWatchConfig struct {
+	Enabled bool      `yaml:"enabled"`
+	Pages   []WebPage `yaml:"pages"`
+}
+
+func init() {
+	Register(Factory[WebWatchConfig]{
+		Type:    SourceWebWatch,
+		Aliases: []string{"web"},
+		Enabled: func(c WebWatchConfig) bool { return c.Enabled },
+		New: func(c WebWatchConfig, deps Deps) ([]Source, error) {
+			return []Source{NewWebWatch(c.Pages, deps.Store)}, nil
+		},
+	})
+}
@@ -6,58 +6,329 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
+// YouTube Data API quota costs per call.
+// See https://developers.google.com/youtube/v3/determine_quota_cost
+const (
+	ytSearchCost = 100
+	ytListCost   = 1
+
+	ytDailyQuota = 10000 // default project quota
+)
+
+// ytQuotaZone is where the daily quota resets at midnight (Pacific Time;
+// standard time all year is at most an hour early).
+var ytQuotaZone = time.FixedZone("PST", -8*60*60)
+
 // YouTube collects trending AI videos from YouTube.
 type YouTube struct {
-	client   *http.Client
-	apiKey   string
-	queries  []string
-	channels []string
+	client      *http.Client
+	apiKey      string
+	queries     []string
+	channels    []string
+	quotaPerRun int
+	dailyQuota  int
+	cursors     *Cursors
+
+	mu        sync.Mutex
+	uploads   map[string]ytChannel // channel ID or handle -> uploads playlist
+	nextQuery int                  // rotates queries when quota is tight
+	quotaDay  string               // day of quotaUsed, in ytQuotaZone
+	quotaUsed int                  // units spent that day
+}
+
+type ytChannel struct {
+	ID       string
+	Title    string
+	Playlist string
 }
 
 // NewYouTube creates a new YouTube collector.
-func NewYouTube(apiKey string, queries, channels []string) *YouTube {
+// channels accepts channel IDs ("UC...") or handles ("@name").
+// dailyQuota is the API units available per day. Collect spreads them over
+// the day, spending at most what has accrued since midnight Pacific Time,
+// and quotaPerRun caps a single call.
+func NewYouTube(apiKey string, queries, channels []string, quotaPerRun, dailyQuota int) *YouTube {
 	if len(queries) == 0 {
 		queries = []string{"AI news", "LLM", "artificial intelligence"}
 	}
+	if quotaPerRun <= 0 {
+		quotaPerRun = 400
+	}
+	if dailyQuota <= 0 {
+		dailyQuota = ytDailyQuota
+	}
 	return &YouTube{
-		client:   &http.Client{Timeout: 30 * time.Second},
-		apiKey:   apiKey,
-		queries:  queries,
-		channels: channels,
+		client:      newHTTPClient(30 * time.Second),
+		apiKey:      apiKey,
+		queries:     queries,
+		channels:    channels,
+		quotaPerRun: quotaPerRun,
+		dailyQuota:  dailyQuota,
+		cursors:     newCursors(nil, ""),
+		uploads:     make(map[string]ytChannel),
 	}
 }
 
 func (y *YouTube) Name() SourceType { return SourceYouTube }
 
+func (y *YouTube) setCursors(c *Cursors) { y.cursors = c }
+
 func (y *YouTube) Collect(ctx context.Context) ([]Item, error) {
 	if y.apiKey == "" {
 		return nil, fmt.Errorf("youtube: API key required (set YOUTUBE_API_KEY)")
 	}
 
+	y.mu.Lock()
+	defer y.mu.Unlock()
+
+	budget, err := y.budget(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if budget < ytListCost {
+		fmt.Printf("  youtube daily quota spent up to now (%d of %d units today)\n", y.quotaUsed, y.dailyQuota)
+		return nil, nil
+	}
+	quota := &ytQuota{remaining: budget}
+	defer func() { y.spend(ctx, budget-quota.remaining) }()
+
 	var allItems []Item
+	seen := make(map[string]bool)
+
+	// Channel uploads first: they are cheap (1-2 units per channel) and
+	// must not be crowded out by searches.
+	for _, channel := range y.channels {
+		items, err := y.channelUploads(ctx, channel, quota)
+		if err != nil {
+			fmt.Printf("  youtube channel %s error: %v\n", channel, err)
+			continue
+		}
+		for _, item := range items {
+			if !seen[item.ExternalID] {
+				seen[item.ExternalID] = true
+				allItems = append(allItems, item)
+			}
+		}
+	}
+
+	// Reserve one unit per 50 videos (20 per search) for the statistics
+	// lookup, then spend the rest on searches. Queries rotate so a small
+	// budget still covers every query over successive runs.
+	statsReserve := ytListCost * ((len(allItems)+20*len(y.queries))/50 + 1)
+	for i := 0; i < len(y.queries); i++ {
+		if quota.remaining-statsReserve < ytSearchCost {
+			fmt.Printf("  youtube quota exhausted, skipping %d queries\n", len(y.queries)-i)
+			break
+		}
+		query := y.queries[y.nextQuery%len(y.queries)]
+		y.nextQuery++
+		quota.take(ytSearchCost)
 
-	// Search for each query.
-	for _, query := range y.queries {
 		items, err := y.search(ctx, query)
 		if err != nil {
 			fmt.Printf("  youtube query %q error: %v\n", query, err)
 			continue
 		}
-		allItems = append(allItems, items...)
+		for _, item := range items {
+			if !seen[item.ExternalID] {
+				seen[item.ExternalID] = true
+				allItems = append(allItems, item)
+			}
+		}
 	}
 
 	// Fetch statistics for all found videos.
 	if len(allItems) > 0 {
-		y.enrichWithStats(ctx, allItems)
+		y.enrichWithStats(ctx, allItems, quota)
 	}
 
 	return allItems, nil
 }
 
+// budget returns the units the next run may spend: the share of the daily
+// quota accrued by now, less what was spent today, up to quotaPerRun. Usage
+// is kept in cursors, so restarts don't reset it.
+func (y *YouTube) budget(ctx context.Context, now time.Time) (int, error) {
+	now = now.In(ytQuotaZone)
+	day := now.Format(time.DateOnly)
+	if y.quotaDay != day {
+		y.quotaDay, y.quotaUsed = day, 0
+		stored, err := y.cursors.Get(ctx, "quota_day")
+		if err != nil {
+			return 0, fmt.Errorf("youtube quota: %w", err)
+		}
+		if stored == day {
+			used, err := y.cursors.Get(ctx, "quota_used")
+			if err != nil {
+				return 0, fmt.Errorf("youtube quota: %w", err)
+			}
+			y.quotaUsed, _ = strconv.Atoi(used)
+		}
+	}
+
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, ytQuotaZone)
+	accrued := int(float64(y.dailyQuota) * now.Sub(midnight).Hours() / 24)
+	return max(0, min(accrued-y.quotaUsed, y.quotaPerRun)), nil
+}
+
+// spend records units spent today.
+func (y *YouTube) spend(ctx context.Context, units int) {
+	y.quotaUsed += units
+	y.cursors.Set(ctx, "quota_day", y.quotaDay)
+	y.cursors.Set(ctx, "quota_used", strconv.Itoa(y.quotaUsed))
+}
+
+// ytQuota tracks API units spent during a single Collect run.
+type ytQuota struct {
+	remaining int
+}
+
+func (q *ytQuota) take(units int) bool {
+	if q.remaining < units {
+		return false
+	}
+	q.remaining -= units
+	return true
+}
+
+// channelUploads returns videos from the channel's uploads playlist
+// published in the last 24 hours.
+func (y *YouTube) channelUploads(ctx context.Context, channel string, quota *ytQuota) ([]Item, error) {
+	ch, err := y.resolveChannel(ctx, channel, quota)
+	if err != nil {
+		return nil, err
+	}
+
+	if !quota.take(ytListCost) {
+		return nil, fmt.Errorf("quota exhausted")
+	}
+
+	params := url.Values{}
+	params.Set("part", "snippet,contentDetails")
+	params.Set("playlistId", ch.Playlist)
+	params.Set("maxResults", "20")
+	params.Set("key", y.apiKey)
+
+	var result ytPlaylistResult
+	if err := y.get(ctx, "playlistItems", params, &result); err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-24 * time.Hour)
+	var items []Item
+	for _, entry := range result.Items {
+		videoID := entry.ContentDetails.VideoID
+		if videoID == "" {
+			continue
+		}
+
+		published := entry.ContentDetails.VideoPublishedAt
+		if published.IsZero() {
+			published = entry.Snippet.PublishedAt
+		}
+		if published.Before(cutoff) {
+			continue
+		}
+
+		title := ch.Title
+		if title == "" {
+			title = entry.Snippet.ChannelTitle
+		}
+
+		items = append(items, Item{
+			ID:          fmt.Sprintf("youtube:%s", videoID),
+			Source:      SourceYouTube,
+			ExternalID:  videoID,
+			Title:       entry.Snippet.Title,
+			URL:         fmt.Sprintf("https://www.youtube.com/watch?v=%s", videoID),
+			Description: truncate(entry.Snippet.Description, 500),
+			Author:      title,
+			Tags:        []string{title},
+			PublishedAt: published.UTC(),
+			CollectedAt: time.Now().UTC(),
+			Extra: map[string]any{
+				"channel_id": ch.ID,
+				"channel":    title,
+			},
+		})
+	}
+
+	return items, nil
+}
+
+// resolveChannel looks up a channel's uploads playlist, caching the result
+// for the lifetime of the collector.
+func (y *YouTube) resolveChannel(ctx context.Context, channel string, quota *ytQuota) (ytChannel, error) {
+	if ch, ok := y.uploads[channel]; ok {
+		return ch, nil
+	}
+
+	if !quota.take(ytListCost) {
+		return ytChannel{}, fmt.Errorf("quota exhausted")
+	}
+
+	params := url.Values{}
+	params.Set("part", "snippet,contentDetails")
+	if strings.HasPrefix(channel, "@") {
+		params.Set("forHandle", channel)
+	} else {
+		params.Set("id", channel)
+	}
+	params.Set("key", y.apiKey)
+
+	var result ytChannelResult
+	if err := y.get(ctx, "channels", params, &result); err != nil {
+		return ytChannel{}, err
+	}
+	if len(result.Items) == 0 {
+		return ytChannel{}, fmt.Errorf("channel not found")
+	}
+
+	c := result.Items[0]
+	ch := ytChannel{
+		ID:       c.ID,
+		Title:    c.Snippet.Title,
+		Playlist: c.ContentDetails.RelatedPlaylists.Uploads,
+	}
+	if ch.Playlist == "" {
+		return ytChannel{}, fmt.Errorf("channel has no uploads playlist")
+	}
+
+	y.uploads[channel] = ch
+	return ch, nil
+}
+
+// get calls a YouTube Data API list endpoint and decodes the JSON response.
+func (y *YouTube) get(ctx context.Context, endpoint string, params url.Values, out any) error {
+	reqURL := "https://www.googleapis.com/youtube/v3/" + endpoint + "?" + params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("create youtube %s request: %w", endpoint, err)
+	}
+
+	resp, err := y.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch youtube %s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return fmt.Errorf("youtube %s: %w", endpoint, err)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode youtube %s: %w", endpoint, err)
+	}
+	return nil
+}
+
 func (y *YouTube) search(ctx context.Context, query string) ([]Item, error) {
 	publishedAfter := time.Now().Add(-24 * time.Hour).Format(time.RFC3339)
 
@@ -82,8 +353,8 @@ func (y *YouTube) search(ctx context.Context, query string) ([]Item, error) {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("youtube search status %d", resp.StatusCode)
+	if err := checkStatus(resp); err != nil {
+		return nil, fmt.Errorf("youtube search: %w", err)
 	}
 
 	var result ytSearchResult
@@ -123,7 +394,7 @@ func (y *YouTube) search(ctx context.Context, query string) ([]Item, error) {
 	return items, nil
 }
 
-func (y *YouTube) enrichWithStats(ctx context.Context, items []Item) {
+func (y *YouTube) enrichWithStats(ctx context.Context, items []Item, quota *ytQuota) {
 	// Collect all video IDs.
 	var ids []string
 	idMap := make(map[string]int)
@@ -139,27 +410,23 @@ func (y *YouTube) enrichWithStats(ctx context.Context, items []Item) {
 			end = len(ids)
 		}
 
+		if !quota.take(ytListCost) {
+			fmt.Printf("  youtube quota exhausted, %d videos without stats\n", len(ids)-start)
+			return
+		}
+
 		batch := ids[start:end]
 		params := url.Values{}
 		params.Set("part", "statistics")
 		params.Set("id", strings.Join(batch, ","))
 		params.Set("key", y.apiKey)
 
-		reqURL := "https://www.googleapis.com/youtube/v3/videos?" + params.Encode()
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
-		if err != nil {
-			continue
-		}
-
-		resp, err := y.client.Do(req)
-		if err != nil {
+		var result ytVideoResult
+		if err := y.get(ctx, "videos", params, &result); err != nil {
+			fmt.Printf("  youtube stats error, %d videos without stats: %v\n", len(batch), err)
 			continue
 		}
 
-		var result ytVideoResult
-		json.NewDecoder(resp.Body).Decode(&result)
-		resp.Body.Close()
-
 		for _, video := range result.Items {
 			if idx, ok := idMap[video.ID]; ok {
 				items[idx].Score = video.Statistics.ViewCount
@@ -186,6 +453,30 @@ type ytSnippet struct {
 	PublishedAt  time.Time `json:"publishedAt"`
 }
 
+type ytPlaylistResult struct {
+	Items []struct {
+		Snippet        ytSnippet `json:"snippet"`
+		ContentDetails struct {
+			VideoID          string    `json:"videoId"`
+			VideoPublishedAt time.Time `json:"videoPublishedAt"`
+		} `json:"contentDetails"`
+	} `json:"items"`
+}
+
+type ytChannelResult struct {
+	Items []struct {
+		ID      string `json:"id"`
+		Snippet struct {
+			Title string `json:"title"`
+		} `json:"snippet"`
+		ContentDetails struct {
+			RelatedPlaylists struct {
+				Uploads string `json:"uploads"`
+			} `json:"relatedPlaylists"`
+		} `json:"contentDetails"`
+	} `json:"items"`
+}
+
 type ytVideoResult struct {
 	Items []struct {
 		ID         string `json:"id"`
@@ -196,3 +487,36 @@ type ytVideoResult struct {
 		} `json:"statistics"`
 	} `json:"items"`
 }
+
+// YouTubeConfig for YouTube collector.
+type YouTubeConfig struct {
+	Enabled     bool     `yaml:"enabled"`
+	APIKey      string   `yaml:"api_key"`
+	Queries     []string `yaml:"queries"`
+	Channels    []string `yaml:"channels"`      // channel IDs ("UC...") or handles ("@name")
+	QuotaPerRun int      `yaml:"quota_per_run"` // API units per collection at most (search = 100, list = 1)
+	DailyQuota  int      `yaml:"daily_quota"`   // API units per day, spread over the day
+}
+
+func init() {
+	Register(Factory[YouTubeConfig]{
+		Type: SourceYouTube,
+		Defaults: func() YouTubeConfig {
+			return YouTubeConfig{
+				Enabled:     false,
+				Queries:     []string{"AI news", "LLM", "artificial intelligence"},
+				QuotaPerRun: 400,
+				DailyQuota:  ytDailyQuota,
+			}
+		},
+		Env: func(c *YouTubeConfig) {
+			if v := os.Getenv("YOUTUBE_API_KEY"); v != "" {
+				c.APIKey = v
+			}
+		},
+		Enabled: func(c YouTubeConfig) bool { return c.Enabled },
+		New: func(c YouTubeConfig, deps Deps) ([]Source, error) {
+			return []Source{NewYouTube(c.APIKey, c.Queries, c.Channels, c.QuotaPerRun, c.DailyQuota)}, nil
+		},
+	})
+}
@@ -0,0 +1,52 @@
+package source
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestYouTubeBudget(t *testing.T) {
+	ctx := context.Background()
+	store := &mapCursorStore{values: map[string]string{}}
+	midnight := time.Date(2026, 5, 4, 0, 0, 0, 0, ytQuotaZone)
+
+	y := NewYouTube("key", nil, nil, 400, 2400) // 100 units an hour
+	y.setCursors(newCursors(store, "youtube"))
+
+	steps := []struct {
+		at    time.Duration // since midnight Pacific
+		spend int
+		want  int
+	}{
+		{at: 30 * time.Minute, want: 50},             // accrued so far
+		{at: 3 * time.Hour, spend: 300, want: 300},   // accrued, unspent
+		{at: 3*time.Hour + 15*time.Minute, want: 25}, // 325 accrued, 300 spent
+		{at: 12 * time.Hour, spend: 400, want: 400},  // capped per run
+		{at: 12*time.Hour + time.Minute, spend: 100, want: 400},
+	}
+	for i, s := range steps {
+		got, err := y.budget(ctx, midnight.Add(s.at))
+		if err != nil {
+			t.Fatalf("step %d: budget: %v", i, err)
+		}
+		if got != s.want {
+			t.Fatalf("step %d: budget = %d, want %d", i, got, s.want)
+		}
+		y.spend(ctx, s.spend)
+		y.cursors.commit(ctx)
+	}
+
+	// A restart the same day picks up the usage: 1200 accrued by noon,
+	// 800 spent.
+	y = NewYouTube("key", nil, nil, 1000, 2400)
+	y.setCursors(newCursors(store, "youtube"))
+	if got, _ := y.budget(ctx, midnight.Add(12*time.Hour+30*time.Minute)); got != 450 {
+		t.Fatalf("budget after restart = %d, want 450", got)
+	}
+
+	// The next day starts over.
+	if got, _ := y.budget(ctx, midnight.Add(25*time.Hour)); got != 100 {
+		t.Fatalf("budget the next day = %d, want 100", got)
+	}
+}
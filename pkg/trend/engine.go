@@ -3,6 +3,7 @@ package trend
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"sort"
 	"strings"
 	"time"
@@ -18,11 +19,13 @@ type Engine struct {
 	velocityWeight    float64
 	crossSourceWeight float64
 	absoluteWeight    float64
-	llm               *LLMEvaluator // optional, nil = disabled
+	llm               *LLMEvaluator      // optional, nil = disabled
+	instanceWeights   map[string]float64 // item weight by source instance, default 1
 }
 
-// NewEngine creates a new trend detection engine.
-func NewEngine(s store.Store, velocityW, crossSourceW, absoluteW float64, llm *LLMEvaluator) *Engine {
+// NewEngine creates a new trend detection engine. instanceWeights scales the
+// items of each named source instance; missing instances weigh 1.
+func NewEngine(s store.Store, velocityW, crossSourceW, absoluteW float64, llm *LLMEvaluator, instanceWeights map[string]float64) *Engine {
 	if velocityW+crossSourceW+absoluteW == 0 {
 		velocityW = 0.3
 		crossSourceW = 0.5
@@ -34,9 +37,18 @@ func NewEngine(s store.Store, velocityW, crossSourceW, absoluteW float64, llm *L
 		crossSourceWeight: crossSourceW,
 		absoluteWeight:    absoluteW,
 		llm:               llm,
+		instanceWeights:   instanceWeights,
 	}
 }
 
+// itemWeight returns the weight of the instance that collected item.
+func (e *Engine) itemWeight(item source.Item) float64 {
+	if w, ok := e.instanceWeights[item.Instance]; ok {
+		return w
+	}
+	return 1
+}
+
 // TopicCluster groups related items from potentially different sources.
 type TopicCluster struct {
 	Topic       string
@@ -177,19 +189,56 @@ func (e *Engine) clusterItems(items []source.Item) []TopicCluster {
 
 	// Tokenize all titles.
 	tokens := make([][]string, n)
+	release := make([]bool, n)
 	for i, item := range items {
 		tokens[i] = significantTokens(item.Title)
+		project, _ := releaseKey(item)
+		release[i] = project != ""
 	}
 
-	// Compare all pairs (O(n²) but n is bounded by 1000).
+	// Compare all pairs (O(n²) but n is bounded by 1000). Release titles
+	// differ from the repository's and other versions' only in the version,
+	// which tokenizing mostly drops, so releases are joined on releaseKey
+	// below instead.
 	for i := 0; i < n; i++ {
 		for j := i + 1; j < n; j++ {
+			if release[i] || release[j] {
+				continue
+			}
 			if jaccardSimilarity(tokens[i], tokens[j]) >= 0.3 {
 				union(i, j)
 			}
 		}
 	}
 
+	// Release announcements rarely share enough words with a release title
+	// to pass the Jaccard threshold, so join them on project + version.
+	for i, item := range items {
+		project, version := releaseKey(item)
+		if project == "" {
+			continue
+		}
+		for j := range items {
+			if j != i && mentionsRelease(items[j].Title, project, version) {
+				union(i, j)
+			}
+		}
+	}
+
+	// Items linking the same page, GitHub repository (the repo itself, a
+	// post about it, a paper whose code lives there) or arXiv paper are one
+	// topic.
+	byKey := make(map[string]int)
+	for i, item := range items {
+		for _, key := range linkKeys(item) {
+			if j, ok := byKey[key]; ok {
+				union(i, j)
+			} else {
+				byKey[key] = i
+			}
+		}
+	}
+
 	// Group by root.
 	groups := make(map[int][]int)
 	for i := 0; i < n; i++ {
@@ -230,9 +279,21 @@ func (e *Engine) clusterItems(items []source.Item) []TopicCluster {
 }
 
 // scoreCluster computes a weighted trend score for a topic cluster.
+// Each part is scaled by the weights of the source instances involved.
 func (e *Engine) scoreCluster(ctx context.Context, cluster TopicCluster) float64 {
-	// 1. Cross-source score (0-100): more sources = higher score.
-	crossScore := float64(len(cluster.Sources)) * 20
+	// 1. Cross-source score (0-100): more sources = higher score. A source
+	// counts with the highest weight among its instances in the cluster.
+	sourceWeights := make(map[source.SourceType]float64)
+	weightedTotal := 0.0
+	for _, item := range cluster.Items {
+		w := e.itemWeight(item)
+		sourceWeights[item.Source] = max(sourceWeights[item.Source], w)
+		weightedTotal += float64(item.Score) * w
+	}
+	crossScore := 0.0
+	for _, w := range sourceWeights {
+		crossScore += 20 * w
+	}
 	if crossScore > 100 {
 		crossScore = 100
 	}
@@ -240,7 +301,7 @@ func (e *Engine) scoreCluster(ctx context.Context, cluster TopicCluster) float64
 	// 2. Velocity score (0-100): based on score growth rate.
 	velocityScore := 0.0
 	for _, item := range cluster.Items {
-		v := e.itemVelocity(ctx, item)
+		v := e.itemVelocity(ctx, item) * e.itemWeight(item)
 		if v > velocityScore {
 			velocityScore = v
 		}
@@ -251,9 +312,9 @@ func (e *Engine) scoreCluster(ctx context.Context, cluster TopicCluster) float64
 
 	// 3. Absolute score (0-100): normalized by item count and source type.
 	absoluteScore := 0.0
-	if cluster.TotalScore > 0 {
+	if weightedTotal > 0 {
 		// Simple heuristic: log scale for normalization.
-		avg := float64(cluster.TotalScore) / float64(len(cluster.Items))
+		avg := weightedTotal / float64(len(cluster.Items))
 		if avg > 1000 {
 			absoluteScore = 100
 		} else if avg > 100 {
@@ -323,6 +384,140 @@ func significantTokens(title string) []string {
 	return tokens
 }
 
+// linkKeys returns the page, GitHub repository and arXiv paper an item is
+// about, taken from its URL or Extra (a cross-post's canonical URL, a
+// paper's code, an HF paper's arXiv ID). Releases and package versions get
+// no repository key: each version is its own story, joined to the rest by
+// releaseKey.
+func linkKeys(item source.Item) []string {
+	var keys []string
+
+	for _, u := range []string{item.URL, extraString(item.Extra, "canonical_url")} {
+		if u = normalizeURL(u); u != "" {
+			keys = append(keys, "url:"+u)
+		}
+	}
+
+	if project, _ := releaseKey(item); project == "" {
+		repo := extraString(item.Extra, "code_repo")
+		if repo == "" {
+			repo = repoURL(item.URL)
+		}
+		if repo != "" {
+			keys = append(keys, "github:"+strings.ToLower(repo))
+		}
+	}
+
+	paper := extraString(item.Extra, "arxiv_id")
+	if paper == "" {
+		paper = source.ArXivIDFromURL(item.URL)
+	}
+	if paper != "" {
+		keys = append(keys, "arxiv:"+paper)
+	}
+
+	return keys
+}
+
+// repoURL returns "owner/repo" when raw is the URL of a GitHub repository
+// itself, not one of its pages (releases, issues, files).
+func repoURL(raw string) string {
+	repo := source.GitHubRepoFromURL(raw)
+	if repo == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	path := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	if !strings.EqualFold(path, repo) {
+		return ""
+	}
+	return repo
+}
+
+// normalizeURL reduces a link to host + path + meaningful query so the same
+// article shared from different places compares equal.
+func normalizeURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+
+	q := u.Query()
+	for k := range q {
+		if strings.HasPrefix(k, "utm_") || k == "ref" || k == "source" {
+			q.Del(k)
+		}
+	}
+
+	s := host + strings.TrimSuffix(u.EscapedPath(), "/")
+	if len(q) > 0 {
+		s += "?" + q.Encode()
+	}
+	return s
+}
+
+func extraString(extra map[string]any, key string) string {
+	s, _ := extra[key].(string)
+	return s
+}
+
+// releaseKey returns the lowercased project name and normalized version of a
+// GitHub release or package version item, or empty strings for any other
+// item.
+func releaseKey(item source.Item) (project, version string) {
+	tag := extraString(item.Extra, "version")
+	if tag == "" {
+		return "", ""
+	}
+	switch item.Source {
+	case source.SourceGitHub:
+		_, project, _ = strings.Cut(extraString(item.Extra, "repo"), "/")
+	case source.SourcePackages:
+		project = strings.TrimPrefix(extraString(item.Extra, "package"), "@")
+	}
+	if project == "" {
+		return "", ""
+	}
+	return strings.ToLower(project), normalizeVersion(tag)
+}
+
+// mentionsRelease reports whether a title names both the project and the
+// version, e.g. "vLLM v0.6.0 is out" for ("vllm", "0.6").
+func mentionsRelease(title, project, version string) bool {
+	title = strings.ToLower(title)
+	if version == "" || !strings.Contains(title, project) {
+		return false
+	}
+	for _, w := range strings.FieldsFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.' && r != '-' && r != '_'
+	}) {
+		if normalizeVersion(w) == version {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeVersion maps "v1.2.0", "1.2" and "1.2.0." to "1.2" so tags match
+// how people write versions in titles.
+func normalizeVersion(s string) string {
+	s = strings.Trim(strings.ToLower(s), ".-_")
+	if len(s) > 1 && s[0] == 'v' && s[1] >= '0' && s[1] <= '9' {
+		s = s[1:]
+	}
+	for strings.HasSuffix(s, ".0") && strings.Count(s, ".") > 1 {
+		s = strings.TrimSuffix(s, ".0")
+	}
+	if !strings.ContainsAny(s, "0123456789") {
+		return ""
+	}
+	return s
+}
+
 // jaccardSimilarity returns the Jaccard index of two token sets.
 func jaccardSimilarity(a, b []string) float64 {
 	if len(a) == 0 || len(b) == 0 {
@@ -0,0 +1,123 @@
+package trend
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/elonfeng/airadar/pkg/source"
+)
+
+func TestLinkKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		item source.Item
+		want []string
+	}{
+		{
+			name: "repository",
+			item: source.Item{Source: source.SourceGitHub, URL: "https://github.com/vllm-project/vllm"},
+			want: []string{"url:github.com/vllm-project/vllm", "github:vllm-project/vllm"},
+		},
+		{
+			name: "post linking a repository",
+			item: source.Item{Source: source.SourceHackerNews, URL: "https://github.com/vllm-project/vllm/"},
+			want: []string{"url:github.com/vllm-project/vllm", "github:vllm-project/vllm"},
+		},
+		{
+			name: "repository sub-page",
+			item: source.Item{Source: source.SourceHackerNews, URL: "https://github.com/vllm-project/vllm/issues/42"},
+			want: []string{"url:github.com/vllm-project/vllm/issues/42"},
+		},
+		{
+			name: "paper with code",
+			item: source.Item{
+				Source: source.SourceArXiv,
+				URL:    "http://arxiv.org/abs/2409.12345v1",
+				Extra:  map[string]any{"arxiv_id": "2409.12345", "code_repo": "org/model"},
+			},
+			want: []string{"url:arxiv.org/abs/2409.12345v1", "github:org/model", "arxiv:2409.12345"},
+		},
+		{
+			name: "release",
+			item: source.Item{
+				Source: source.SourceGitHub,
+				URL:    "https://github.com/vllm-project/vllm/releases/tag/v0.6.0",
+				Extra:  map[string]any{"repo": "vllm-project/vllm", "version": "v0.6.0"},
+			},
+			want: []string{"url:github.com/vllm-project/vllm/releases/tag/v0.6.0"},
+		},
+		{
+			name: "package version",
+			item: source.Item{
+				Source: source.SourcePackages,
+				URL:    "https://pypi.org/project/vllm/0.6.0/",
+				Extra:  map[string]any{"package": "vllm", "version": "0.6.0", "code_repo": "vllm-project/vllm"},
+			},
+			want: []string{"url:pypi.org/project/vllm/0.6.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := linkKeys(tt.item); !slices.Equal(got, tt.want) {
+				t.Errorf("linkKeys = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterItemsKeepsVersionsApart(t *testing.T) {
+	release := func(tag string) source.Item {
+		return source.Item{
+			ID:     "github:vllm-project/vllm@" + tag,
+			Source: source.SourceGitHub,
+			Title:  "vllm " + tag,
+			URL:    "https://github.com/vllm-project/vllm/releases/tag/" + tag,
+			Extra:  map[string]any{"repo": "vllm-project/vllm", "version": tag},
+		}
+	}
+	items := []source.Item{
+		{
+			ID:     "github:vllm-project/vllm",
+			Source: source.SourceGitHub,
+			Title:  "vllm-project/vllm",
+			URL:    "https://github.com/vllm-project/vllm",
+		},
+		release("v0.6.0"),
+		release("v0.7.0"),
+		{
+			ID:     "packages:pypi:vllm@0.6.0",
+			Source: source.SourcePackages,
+			Title:  "vllm 0.6.0",
+			URL:    "https://pypi.org/project/vllm/0.6.0/",
+			Extra:  map[string]any{"package": "vllm", "version": "0.6.0", "code_repo": "vllm-project/vllm"},
+		},
+		{
+			ID:     "hackernews:1",
+			Source: source.SourceHackerNews,
+			Title:  "vLLM v0.7.0 released with faster decoding",
+			URL:    "https://blog.vllm.ai/2026/10/01/v0.7.0",
+		},
+	}
+
+	clusterOf := make(map[string]int)
+	for i, c := range (&Engine{}).clusterItems(items) {
+		for _, item := range c.Items {
+			clusterOf[item.ID] = i
+		}
+	}
+
+	same := func(a, b string) bool { return clusterOf[a] == clusterOf[b] }
+	if same("github:vllm-project/vllm@v0.6.0", "github:vllm-project/vllm@v0.7.0") {
+		t.Error("two versions share a cluster")
+	}
+	if same("github:vllm-project/vllm", "github:vllm-project/vllm@v0.6.0") {
+		t.Error("release joined the repository's cluster")
+	}
+	if !same("github:vllm-project/vllm@v0.6.0", "packages:pypi:vllm@0.6.0") {
+		t.Error("release and package of the same version are apart")
+	}
+	if !same("github:vllm-project/vllm@v0.7.0", "hackernews:1") {
+		t.Error("announcement not joined with its release")
+	}
+}
@@ -10,13 +10,31 @@ func NormalizeScore(score int, sourceType string) float64 {
 	// - Reddit: 1-100k+ (1000 is high for AI subs)
 	// - GitHub: stars 0-100k+ (100 new stars/week is high)
 	// - YouTube: views 0-millions (10k is decent for AI)
-	// - ArXiv/RSS/Twitter: no native scores
+	// - Hugging Face: likes 0-10k+ (100 likes is high for a new repo)
+	// - Bluesky: likes+reposts 0-10k+ (200 is high)
+	// - Mastodon: favourites+boosts 0-1k+ (100 is high)
+	// - Product Hunt: votes 0-5k+ (300 is a strong launch day)
+	// - ArXiv: citations + GitHub stars of linked code (100 is high for a new paper)
+	// - HF Daily Papers: upvotes 0-500+ (50 is a top paper of the day)
+	// - Lobste.rs: 1-200+ (50 is a front-page hit)
+	// - Dev.to: reactions 0-1k+ (100 is a top article of the day)
+	// - OpenReview: mean rating x10 (80 is a likely oral/spotlight)
+	// - RSS/Twitter/packages/webwatch/newsletter: no native scores (downloads are context in Extra)
 
 	thresholds := map[string]float64{
-		"hackernews": 500,
-		"reddit":     1000,
-		"github":     100,
-		"youtube":    10000,
+		"hackernews":  500,
+		"reddit":      1000,
+		"github":      100,
+		"youtube":     10000,
+		"huggingface": 100,
+		"bluesky":     200,
+		"mastodon":    100,
+		"producthunt": 300,
+		"arxiv":       100,
+		"hfpapers":    50,
+		"lobsters":    50,
+		"devto":       100,
+		"openreview":  80,
 	}
 
 	threshold, ok := thresholds[sourceType]